@@ -0,0 +1,227 @@
+package tables
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+const ErrInvalidRollCount = TableError("roll count must be at least 1")
+const ErrHistogramDoesNotMatchTable = TableError("histogram does not have a count for every row of the table")
+
+//Histogram counts the results of rolling a table many times.
+type Histogram struct {
+	//Rolls is the number of times the table was rolled.
+	Rolls int
+	//Values counts each value rolled using the table's roll expression.
+	Values map[int]int
+	//Rows counts how many times each row was rolled, in the same order as the table's rows.
+	Rows []int
+	//Misses counts the rolls that did not match a row.
+	Misses int
+}
+
+//ChiSquare is the result of a chi-square goodness of fit test.
+type ChiSquare struct {
+	Statistic        float64
+	DegreesOfFreedom int
+	//PValue is the probability of a statistic at least this large if the rolls follow the expected distribution,
+	//a small value (e.g. below 0.01) means they probably do not.
+	PValue float64
+}
+
+//RollN rolls the table n times using r, the same as RandomRowWith, and counts the rolled values and rows. Rows are
+//only matched and not returned, so roll expressions in their cells are not rolled. A nil r uses the dice package.
+func (t Table) RollN(r Rand, n int) (Histogram, error) {
+	if n < 1 {
+		return Histogram{}, ErrInvalidRollCount
+	}
+
+	h := Histogram{Values: make(map[int]int), Rows: make([]int, len(t.Rows))}
+	t.rollInto(&h, r, n, t.rowLookup())
+
+	return h, nil
+}
+
+//RollNParallel rolls the table n times the same as RollN, split across workers that each roll with a source seeded
+//from seed (the first worker uses seed, the next seed+1, and so on). The same seed and number of workers always
+//produce the same histogram.
+func (t Table) RollNParallel(seed int64, n, workers int) (Histogram, error) {
+	if n < 1 {
+		return Histogram{}, ErrInvalidRollCount
+	}
+	workers = max(1, min(workers, n))
+
+	lookup := t.rowLookup()
+	histograms := make([]Histogram, workers)
+	var wg sync.WaitGroup
+	for i := range histograms {
+		histograms[i] = Histogram{Values: make(map[int]int), Rows: make([]int, len(t.Rows))}
+		rolls := n / workers
+		if i < n%workers {
+			rolls++
+		}
+
+		wg.Add(1)
+		go func(h *Histogram, r Rand, rolls int) {
+			defer wg.Done()
+			t.rollInto(h, r, rolls, lookup)
+		}(&histograms[i], NewRand(seed+int64(i)), rolls)
+	}
+	wg.Wait()
+
+	h := histograms[0]
+	for _, other := range histograms[1:] {
+		h.Rolls += other.Rolls
+		h.Misses += other.Misses
+		for value, count := range other.Values {
+			h.Values[value] += count
+		}
+		for i, count := range other.Rows {
+			h.Rows[i] += count
+		}
+	}
+
+	return h, nil
+}
+
+//ChiSquare tests how well the rolls in h fit the probabilities of the table's rows (see Probabilities). Rolls that
+//did not match a row are counted as an extra category when the roll expression can miss every row.
+func (t Table) ChiSquare(h Histogram) (ChiSquare, error) {
+	if len(h.Rows) != len(t.Rows) {
+		return ChiSquare{}, ErrHistogramDoesNotMatchTable
+	}
+
+	probabilities, err := t.Probabilities()
+	if err != nil {
+		return ChiSquare{}, err
+	}
+
+	observed := append([]int(nil), h.Rows...)
+	total := 0.0
+	for _, probability := range probabilities {
+		total += probability
+	}
+	if miss := 1 - total; miss > 1e-9 || h.Misses > 0 {
+		observed = append(observed, h.Misses)
+		probabilities = append(probabilities, math.Max(miss, 0))
+	}
+
+	result := ChiSquare{}
+	categories := 0
+	for i, count := range observed {
+		expected := probabilities[i] * float64(h.Rolls)
+		if expected == 0 {
+			if count > 0 {
+				result.Statistic = math.Inf(1)
+			}
+			continue
+		}
+		categories++
+		difference := float64(count) - expected
+		result.Statistic += difference * difference / expected
+	}
+
+	result.DegreesOfFreedom = max(categories-1, 0)
+	result.PValue = chiSquarePValue(result.Statistic, result.DegreesOfFreedom)
+
+	return result, nil
+}
+
+//rollInto adds n rolls of the table to h using r.
+func (t Table) rollInto(h *Histogram, r Rand, n int, lookup map[int]int) {
+	rollExpression := t.Meta.RollExpression
+	if !t.Meta.RollableTable {
+		rollExpression = fmt.Sprintf("1d%d", len(t.Rows))
+	}
+
+	for i := 0; i < n; i++ {
+		value, _ := rollWith(r, rollExpression)
+		h.Rolls++
+		h.Values[value]++
+
+		index, ok := lookup[value]
+		if !ok {
+			h.Misses++
+			continue
+		}
+		h.Rows[index]++
+	}
+}
+
+//rowLookup returns the index of the row matched by each value, the same rows rowIndex would return.
+func (t Table) rowLookup() map[int]int {
+	lookup := make(map[int]int)
+	//rowIndex returns the first row that matches, so rows are added in reverse to let earlier rows win
+	for i := len(t.Rows) - 1; i >= 0; i-- {
+		if !RangedRoll(t.Rows[i].RollRange) {
+			continue
+		}
+		start, end := rowBounds(t.Rows[i])
+		for value := start; value <= end; value++ {
+			lookup[value] = i
+		}
+	}
+	//an exact die roll takes precedence over a range
+	for i := len(t.Rows) - 1; i >= 0; i-- {
+		lookup[t.Rows[i].DieRoll] = i
+	}
+
+	return lookup
+}
+
+//chiSquarePValue returns the probability of a chi-square statistic of at least x with df degrees of freedom,
+//the regularized upper incomplete gamma function Q(df/2, x/2).
+func chiSquarePValue(x float64, df int) float64 {
+	if df < 1 || math.IsInf(x, 1) {
+		if x > 0 {
+			return 0
+		}
+		return 1
+	}
+	if x <= 0 {
+		return 1
+	}
+
+	a, x := float64(df)/2, x/2
+	lgamma, _ := math.Lgamma(a)
+	if x < a+1 {
+		//series for the lower incomplete gamma function
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+a*math.Log(x)-lgamma)
+	}
+
+	//continued fraction for the upper incomplete gamma function (Lentz's method)
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	f := d
+	for n := 1; n < 1000; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		f *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+
+	return math.Exp(-x+a*math.Log(x)-lgamma) * f
+}
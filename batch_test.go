@@ -0,0 +1,156 @@
+package tables
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTable_RollN(t *testing.T) {
+	ranged, _ := Load(rangedCSV, "ranged", "Ranged", "d20")
+
+	t.Run("validate every roll is counted", func(t *testing.T) {
+		got, err := ranged.RollN(NewRand(1), 1000)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		rows, values := 0, 0
+		for _, count := range got.Rows {
+			rows += count
+		}
+		for value, count := range got.Values {
+			values += count
+			if value < 1 || value > 20 {
+				t.Errorf("want values from 1 to 20, got %d", value)
+			}
+		}
+		if got.Rolls != 1000 || rows+got.Misses != 1000 || values != 1000 {
+			t.Errorf("want 1000 rolls, got %d rolls, %d rows, %d misses, %d values", got.Rolls, rows, got.Misses, values)
+		}
+	})
+
+	t.Run("validate rolls match the same rows as GetRow", func(t *testing.T) {
+		got, _ := ranged.RollN(NewRand(2), 500)
+		for value, count := range got.Values {
+			index := ranged.rowIndex(value)
+			if index < 0 {
+				continue
+			}
+			if got.Rows[index] < count {
+				t.Errorf("want at least %d rolls of row %d, got %d", count, index, got.Rows[index])
+			}
+		}
+	})
+
+	t.Run("validate seeded rolls are repeated", func(t *testing.T) {
+		want, _ := ranged.RollN(NewRand(3), 100)
+		got, _ := ranged.RollN(NewRand(3), 100)
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate an error is returned for a roll count less than 1", func(t *testing.T) {
+		_, err := ranged.RollN(nil, 0)
+		if err != ErrInvalidRollCount {
+			t.Errorf("want %v, got %v", ErrInvalidRollCount, err)
+		}
+	})
+}
+
+func TestTable_RollNParallel(t *testing.T) {
+	table, _ := Load(testCSV, "test", "Test", "d6")
+
+	t.Run("validate rolls are split across workers", func(t *testing.T) {
+		got, err := table.RollNParallel(7, 1001, 4)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		rows := 0
+		for _, count := range got.Rows {
+			rows += count
+		}
+		if got.Rolls != 1001 || rows != 1001 || got.Misses != 0 {
+			t.Errorf("want 1001 rolls, got %d rolls, %d rows, %d misses", got.Rolls, rows, got.Misses)
+		}
+	})
+
+	t.Run("validate the same seed and workers are repeated", func(t *testing.T) {
+		want, _ := table.RollNParallel(7, 1000, 3)
+		got, _ := table.RollNParallel(7, 1000, 3)
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate an error is returned for a roll count less than 1", func(t *testing.T) {
+		_, err := table.RollNParallel(7, -1, 3)
+		if err != ErrInvalidRollCount {
+			t.Errorf("want %v, got %v", ErrInvalidRollCount, err)
+		}
+	})
+}
+
+func TestTable_ChiSquare(t *testing.T) {
+	table, _ := Load(testCSV, "test", "Test", "2d6")
+
+	t.Run("validate rolls fit the table's distribution", func(t *testing.T) {
+		h, _ := table.RollNParallel(11, 60000, 4)
+		got, err := table.ChiSquare(h)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		//rows 1 to 6 and the rolls from 7 to 12 that miss every row
+		if got.DegreesOfFreedom != 5 {
+			t.Errorf("want 5, got %d", got.DegreesOfFreedom)
+		}
+		if got.PValue < 0.001 {
+			t.Errorf("want a p-value of at least 0.001, got %f (statistic %f)", got.PValue, got.Statistic)
+		}
+	})
+
+	t.Run("validate rolls that do not fit the table's distribution are found", func(t *testing.T) {
+		d6, _ := Load(testCSV, "test", "Test", "d6")
+		h := Histogram{Rolls: 600, Rows: []int{200, 80, 80, 80, 80, 80}}
+
+		got, err := d6.ChiSquare(h)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if got.PValue > 0.001 {
+			t.Errorf("want a p-value below 0.001, got %f", got.PValue)
+		}
+	})
+
+	t.Run("validate an error is returned for a histogram from another table", func(t *testing.T) {
+		_, err := table.ChiSquare(Histogram{Rows: []int{1}})
+		if err != ErrHistogramDoesNotMatchTable {
+			t.Errorf("want %v, got %v", ErrHistogramDoesNotMatchTable, err)
+		}
+	})
+}
+
+func Test_chiSquarePValue(t *testing.T) {
+	tests := []struct {
+		x    float64
+		df   int
+		want float64
+	}{
+		{x: 3.841459, df: 1, want: 0.05},
+		{x: 18.307038, df: 10, want: 0.05},
+		{x: 1.386294, df: 2, want: 0.5},
+		{x: 0, df: 3, want: 1},
+		{x: 50.892181, df: 30, want: 0.01},
+	}
+
+	for _, test := range tests {
+		got := chiSquarePValue(test.x, test.df)
+		//the statistics are only given to 6 decimal places
+		if math.Abs(test.want-got) > 1e-6 {
+			t.Errorf("want %f, got %f", test.want, got)
+		}
+	}
+}
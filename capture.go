@@ -0,0 +1,89 @@
+package tables
+
+import (
+	"regexp"
+	"strconv"
+)
+
+var (
+	//RowCaptureRE matches a named capture in a row (e.g. {{n=1d6}}), the roll is made once and shared by every cell of the row.
+	RowCaptureRE = regexp.MustCompile(`{{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*=\s*([^{}]+?)\s*}}`)
+	//RowReferenceRE matches a reference to a named capture (e.g. {{n}}, {{n*10}}), with an optional integer operation.
+	RowReferenceRE = regexp.MustCompile(`{{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(?:([-+*/])\s*([0-9]+))?\s*}}`)
+)
+
+//rollRowWith returns results with every capture, reference, and roll expression replaced by its value using r.
+//Captures are rolled first, in column order, so a capture can be referenced from any cell in the row (e.g.
+//"{{n=1d6}} goblins" and "{{n*10}} gp"). A capture that is not a valid roll expression and a reference to a capture
+//that is not in the row are left unchanged.
+func rollRowWith(r Rand, results []string) []string {
+	captures := make(map[string]int)
+	for _, result := range results {
+		for _, match := range RowCaptureRE.FindAllStringSubmatch(result, -1) {
+			name, expression := match[1], match[2]
+			if _, ok := captures[name]; ok || !captureName(name) || !diceExpression(expression) {
+				continue
+			}
+			captures[name], _ = rollWith(r, expression)
+		}
+	}
+
+	rolled := make([]string, 0, len(results))
+	for _, result := range results {
+		if len(captures) > 0 {
+			result = RowCaptureRE.ReplaceAllStringFunc(result, func(m string) string {
+				value, ok := captures[RowCaptureRE.FindStringSubmatch(m)[1]]
+				if !ok {
+					return m
+				}
+				return strconv.Itoa(value)
+			})
+			result = RowReferenceRE.ReplaceAllStringFunc(result, func(m string) string {
+				match := RowReferenceRE.FindStringSubmatch(m)
+				value, ok := captures[match[1]]
+				if !ok {
+					return m
+				}
+				value, ok = operate(value, match[2], match[3])
+				if !ok {
+					return m
+				}
+				return strconv.Itoa(value)
+			})
+		}
+		rolled = append(rolled, rollStringWith(r, result))
+	}
+
+	return rolled
+}
+
+//captureName returns true if name can be used for a capture, names that are roll expressions (e.g. d6) can not.
+func captureName(name string) bool {
+	return !diceExpression(name)
+}
+
+//operate applies the operation op with operand to value, false is returned if it can not be applied.
+func operate(value int, op, operand string) (int, bool) {
+	if op == "" {
+		return value, true
+	}
+
+	n, err := strconv.Atoi(operand)
+	if err != nil {
+		return 0, false
+	}
+
+	switch op {
+	case "+":
+		return value + n, true
+	case "-":
+		return value - n, true
+	case "*":
+		return value * n, true
+	default:
+		if n == 0 {
+			return 0, false
+		}
+		return value / n, true
+	}
+}
@@ -0,0 +1,77 @@
+package tables
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_GetRow_captures(t *testing.T) {
+	records := [][]string{
+		{"D4", "Encounter", "Treasure"},
+		{"1", "{{n=1d1+2}} goblins led by a goblin with {{hp = 1d1+5}} HP", "{{n*10}} gp"},
+		{"2", "{{n}} goblins and {{2d1}} wolves", "{{ n + 1 }} gp"},
+		{"3", "{{n=1d1}} {{n=1d1+8}} {{n}} {{n-1}} {{n/0}}", "{{d6=1d1}}"},
+		{"4", "{{x=4d1}} hobgoblins, {{x/2}} archers", "{{y}} {{x}}"},
+	}
+	table, err := Load(records, "captures", "Captures", "d4")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	tests := []struct {
+		name string
+		roll int
+		want []string
+	}{
+		{name: "validate captures are shared across cells", roll: 1, want: []string{"1", "3 goblins led by a goblin with 6 HP", "30 gp"}},
+		{name: "validate references without a capture are left unchanged", roll: 2, want: []string{"2", "{{n}} goblins and 2 wolves", "{{ n + 1 }} gp"}},
+		{name: "validate the first capture of a name is used", roll: 3, want: []string{"3", "1 1 1 0 {{n/0}}", "{{d6=1d1}}"}},
+		{name: "validate references can come before their capture", roll: 4, want: []string{"4", "4 hobgoblins, 2 archers", "{{y}} 4"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := table.GetRowWith(NewRand(1), test.roll)
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	t.Run("validate a capture is rolled once per row result", func(t *testing.T) {
+		goblins, _ := Load([][]string{{"D1", "Goblins", "Treasure"}, {"1", "{{n=1d100}}", "{{n}}"}}, "goblins", "Goblins", "d1")
+		rows, err := goblins.ExpressionWith(NewRand(3), "20?goblins")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		for _, row := range rows[1:] {
+			if row[1] != row[2] {
+				t.Errorf("want %s, got %s", row[1], row[2])
+			}
+		}
+	})
+}
+
+func TestRollableString_captures(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "{{n=1d6}} goblins", want: true},
+		{value: "{{n*10}} gp", want: false},
+		{value: "{{1d6}} gp", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run("validate rollable string "+test.value, func(t *testing.T) {
+			got := RollableString(test.value)
+			if test.want != got {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
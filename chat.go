@@ -0,0 +1,207 @@
+package tables
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fantastical-world/dice"
+)
+
+const ErrChatRollLimit = TableError("expression rolls more than a chat message allows")
+
+//MaxChatRolls is the most dice or table rows a single expression in a chat message may roll, it keeps a bot from
+//being asked to roll a million rows.
+const MaxChatRolls = 100
+
+//ChatExpression is a table or dice expression found in a chat message.
+type ChatExpression struct {
+	//Expression is the expression as it appears in the message, without any surrounding punctuation or braces.
+	Expression string
+	//Table is true for table expressions (e.g. 2?treasure) and false for dice expressions (e.g. 1d6).
+	Table bool
+}
+
+//ChatResult is the result of executing a ChatExpression.
+type ChatResult struct {
+	ChatExpression
+	//Meta is the meta data of the table that was rolled, it is only set for table expressions.
+	Meta Meta
+	//Records are the rolled rows, with the table headers as the first record. It is only set for table expressions.
+	Records [][]string
+	//Total is the rolled value of a dice expression.
+	Total int
+	//Err is set if the expression could not be executed.
+	Err error
+}
+
+//ParseChat returns every table and dice expression in message, in the order they appear.
+//Words are split on whitespace and trimmed of surrounding punctuation, so "/roll 2?treasure and {{1d6}} gold!"
+//finds 2?treasure and 1d6. Table expressions using # must include a number, so channel names like #general are ignored.
+func ParseChat(message string) []ChatExpression {
+	var expressions []ChatExpression
+	for _, word := range strings.Fields(message) {
+		word = strings.Trim(word, "()[]\"'`*_~,;:!.")
+		//a trailing ? is a question mark, a table expression always has a name after it
+		word = strings.TrimRight(word, "?")
+		if strings.HasPrefix(word, "{{") && strings.HasSuffix(word, "}}") {
+			word = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(word, "{{"), "}}"))
+		}
+
+		switch {
+		case chatTableExpression(word):
+			expressions = append(expressions, ChatExpression{Expression: word, Table: true})
+		case diceExpression(word):
+			expressions = append(expressions, ChatExpression{Expression: word})
+		}
+	}
+
+	return expressions
+}
+
+//Chat executes every expression in message against the library (see ParseChat), using r for every roll.
+//A nil r uses the dice package. An expression that fails does not stop the others, its error is set on its result.
+//Chat messages are usually shared, so tables are shown as AudiencePlayer sees them, use ChatFor for another audience.
+func (l *Library) Chat(r Rand, message string) []ChatResult {
+	return l.ChatFor(r, message, AudiencePlayer)
+}
+
+//ChatFor executes every expression in message the same as Chat, showing the rolled tables as audience sees them
+//(see View).
+func (l *Library) ChatFor(r Rand, message string, audience Audience) []ChatResult {
+	library := l.Snapshot()
+	var results []ChatResult
+	for _, expression := range ParseChat(message) {
+		result := ChatResult{ChatExpression: expression}
+		if chatRolls(expression) > MaxChatRolls {
+			result.Err = ErrChatRollLimit
+			results = append(results, result)
+			continue
+		}
+
+		if expression.Table {
+			table, err := library.Resolve(ParseTablename(expression.Expression))
+			if err == nil {
+				result.Meta = table.View(audience).Meta
+				result.Records, err = table.ExpressionWith(r, expression.Expression)
+			}
+			if err == nil {
+				result.Records = table.ViewRecords(result.Records, audience)
+			}
+			result.Err = err
+		} else {
+			result.Total, result.Err = rollWith(r, expression.Expression)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+//FormatChat returns results as Markdown suitable for chat services. Tables are shown with their display name,
+//flavor text, and each rolled row as a list item of header and value pairs, since most chat services do not render
+//Markdown tables. An empty string is returned if there are no results.
+func FormatChat(results []ChatResult) string {
+	var b strings.Builder
+	for i, result := range results {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		if result.Err != nil {
+			fmt.Fprintf(&b, "`%s` %s\n", result.Expression, result.Err)
+			continue
+		}
+
+		if !result.Table {
+			fmt.Fprintf(&b, "`%s` **%d**\n", result.Expression, result.Total)
+			continue
+		}
+
+		name := result.Meta.DisplayName
+		if name == "" {
+			name = result.Meta.Name
+		}
+		fmt.Fprintf(&b, "**%s** `%s`\n", escapeChat(name), result.Expression)
+		if result.Meta.FlavorText != "" {
+			fmt.Fprintf(&b, "> %s\n", escapeChat(result.Meta.FlavorText))
+		}
+		headers := result.Records[0]
+		for _, record := range result.Records[1:] {
+			var values []string
+			for j, value := range record {
+				if j < len(headers) && headers[j] != "" {
+					values = append(values, fmt.Sprintf("**%s:** %s", escapeChat(headers[j]), escapeChat(value)))
+					continue
+				}
+				values = append(values, escapeChat(value))
+			}
+			fmt.Fprintf(&b, "- %s\n", strings.Join(values, ", "))
+		}
+	}
+
+	return b.String()
+}
+
+//chatTableExpression returns true if word is a table expression that should be rolled from a chat message.
+func chatTableExpression(word string) bool {
+	te := strings.TrimPrefix(word, "uni:")
+	match := TableRollExpressionRE.FindStringSubmatch(te)
+	if match == nil {
+		return false
+	}
+
+	return match[2] == "?" || match[1] != ""
+}
+
+//diceExpression returns true if word is a dice expression, with or without a dice prefix (e.g. max:2d6).
+func diceExpression(word string) bool {
+	for _, prefix := range []string{"max:", "min:", "half:", "dub:", "dropL:", "dropH:"} {
+		word = strings.TrimPrefix(word, prefix)
+	}
+
+	return dice.RollExpressionRE.MatchString(word)
+}
+
+//chatRolls returns the number of dice or table rows expression rolls, a count that is more than MaxChatRolls or can't
+//be read is returned as MaxChatRolls+1 so counts are never added past the limit.
+func chatRolls(expression ChatExpression) int {
+	if expression.Table {
+		return min(ExpressionRows(expression.Expression), MaxChatRolls+1)
+	}
+
+	match := dice.RollExpressionRE.FindStringSubmatch(expression.Expression[strings.LastIndex(expression.Expression, ":")+1:])
+	numbers := []string{match[1]}
+	if match[5] != "" {
+		numbers = append(numbers, match[7])
+	}
+	rolls := 0
+	for _, number := range numbers {
+		n := 1
+		if number != "" {
+			var err error
+			n, err = strconv.Atoi(number)
+			if err != nil || n > MaxChatRolls {
+				return MaxChatRolls + 1
+			}
+		}
+		rolls += n
+	}
+
+	return rolls
+}
+
+//escapeChat escapes the Markdown characters chat services format in value, and replaces line breaks with spaces.
+func escapeChat(value string) string {
+	value = strings.NewReplacer("\r\n", " ", "\n", " ").Replace(value)
+
+	var b strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune("\\*_~`|>", r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
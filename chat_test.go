@@ -0,0 +1,148 @@
+package tables
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChat(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []ChatExpression
+	}{
+		{
+			name:    "validate table and dice expressions are found in order",
+			message: "/roll 2?treasure and 1d6 gold",
+			want:    []ChatExpression{{Expression: "2?treasure", Table: true}, {Expression: "1d6"}},
+		},
+		{
+			name:    "validate punctuation and braces are trimmed from expressions",
+			message: "what about (3#encounters), **uni:2?loot** or {{max:2d6+1}}?",
+			want:    []ChatExpression{{Expression: "3#encounters", Table: true}, {Expression: "uni:2?loot", Table: true}, {Expression: "max:2d6+1"}},
+		},
+		{
+			name:    "validate channel names and questions are not expressions",
+			message: "anyone in #general want to roll? maybe 1d20+2d4.",
+			want:    []ChatExpression{{Expression: "1d20+2d4"}},
+		},
+		{
+			name:    "validate nothing is found in a message without expressions",
+			message: "good game everyone",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ParseChat(test.message)
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestLibrary_Chat(t *testing.T) {
+	encounters, _ := Load(testCSV, "encounters", "Encounters", "d6")
+	encounters.Meta.FlavorText = "Things found *on* the road."
+	library := NewLibrary(encounters)
+
+	t.Run("validate every expression in a message is executed", func(t *testing.T) {
+		got := library.Chat(NewRand(1), "rolling 4#encounters with 1d1+2 and 2?nope")
+
+		want := []ChatResult{
+			{ChatExpression: ChatExpression{Expression: "4#encounters", Table: true}, Meta: encounters.Meta, Records: [][]string{testCSV[0], {"4", "2 bats attack", "Angry bats swarm and attack the party."}}},
+			{ChatExpression: ChatExpression{Expression: "1d1+2"}, Total: 3},
+			{ChatExpression: ChatExpression{Expression: "2?nope", Table: true}, Err: ErrTableDoesNotExist},
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate seeded chat rolls are repeated", func(t *testing.T) {
+		want := library.Chat(NewRand(42), "3?encounters 4d6")
+		got := library.Chat(NewRand(42), "3?encounters 4d6")
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate hidden columns are only shown to their audience", func(t *testing.T) {
+		library := NewLibrary(testTrapsTable(t))
+
+		got := library.Chat(nil, "2#traps")
+		want := [][]string{{"D3", "Trap", "Effect"}, {"2", "Darts", "Poison"}}
+		if len(got) != 1 || !reflect.DeepEqual(want, got[0].Records) || !reflect.DeepEqual(want[0], got[0].Meta.Headers) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+
+		got = library.ChatFor(nil, "2#traps", AudienceGM)
+		want = [][]string{{"D3", "Trap", "Trap DC", "Effect"}, {"2", "Darts", "15", "Poison"}}
+		if len(got) != 1 || !reflect.DeepEqual(want, got[0].Records) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate expressions over the roll limit are not rolled", func(t *testing.T) {
+		got := library.Chat(nil, "101?encounters 60d6+41d4 100d6")
+
+		want := []error{ErrChatRollLimit, ErrChatRollLimit, nil}
+		for i, result := range got {
+			if result.Err != want[i] {
+				t.Errorf("want %v, got %v", want[i], result.Err)
+			}
+		}
+	})
+
+	t.Run("validate counts too large to add are over the roll limit", func(t *testing.T) {
+		got := library.Chat(NewRand(1), "roll 99999999999999999999d6+d6 now, or 99999999999999999999?encounters")
+
+		if len(got) != 2 {
+			t.Fatalf("want 2 results, got %v", got)
+		}
+		for _, result := range got {
+			if result.Err != ErrChatRollLimit {
+				t.Errorf("want %v, got %v", ErrChatRollLimit, result.Err)
+			}
+		}
+	})
+}
+
+func TestFormatChat(t *testing.T) {
+	t.Run("validate results are formatted as chat markdown", func(t *testing.T) {
+		results := []ChatResult{
+			{
+				ChatExpression: ChatExpression{Expression: "2?treasure", Table: true},
+				Meta:           Meta{Name: "treasure", DisplayName: "Treasure", FlavorText: "Loot\nfor *everyone*"},
+				Records:        [][]string{{"D6", "Item"}, {"1", "gold_coins"}, {"4", "a gem"}},
+			},
+			{ChatExpression: ChatExpression{Expression: "1d6"}, Total: 4},
+			{ChatExpression: ChatExpression{Expression: "1?loot", Table: true}, Meta: Meta{Name: "loot"}, Records: [][]string{{"", "Item"}, {"1", "rope"}}},
+			{ChatExpression: ChatExpression{Expression: "2?nope", Table: true}, Err: ErrTableDoesNotExist},
+		}
+
+		want := "**Treasure** `2?treasure`\n" +
+			"> Loot for \\*everyone\\*\n" +
+			"- **D6:** 1, **Item:** gold\\_coins\n" +
+			"- **D6:** 4, **Item:** a gem\n" +
+			"\n" +
+			"`1d6` **4**\n" +
+			"\n" +
+			"**loot** `1?loot`\n" +
+			"- 1, **Item:** rope\n" +
+			"\n" +
+			"`2?nope` table does not exist\n"
+		got := FormatChat(results)
+		if want != got {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	})
+
+	t.Run("validate nothing is formatted without results", func(t *testing.T) {
+		got := FormatChat(nil)
+		if got != "" {
+			t.Errorf("want empty string, got %q", got)
+		}
+	})
+}
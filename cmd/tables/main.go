@@ -0,0 +1,450 @@
+//Command tables rolls, inspects, and converts tables from the command line.
+//
+//Tables are read from a library directory (-lib, or $TABLES_LIBRARY) and individual files (-file), in any of the
+//formats supported by tables.Decode. Each table is named after its file, so loot.csv is rolled with 3?loot.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fantastical-world/tables"
+)
+
+const usage = `usage: tables <command> [flags] [arguments]
+
+commands:
+  roll <expression>...         roll table expressions (e.g. 3?loot, uni:2?npcs, 4#weather)
+  show [-format f] <table>     print a table (text, csv, json, markdown, or yaml)
+  validate [table]...          check tables for errors, all tables are checked if none are named
+  stats <table>                print the probability of each row of a table
+  simulate [-n runs] [-seed s] <expression>
+                               run a table expression many times and summarize its results
+  find <table> <query>         print the rows of a table matching a query (e.g. dragon, Monster=/^red/)
+  search [-campaign c] [-n limit] <query>...
+                               find tables by name, title, headers, or rows
+  convert [-to f] [-o path] <file>
+                               convert a table file to another format
+  repl                         start an interactive session for rolling tables
+
+flags for roll, show, validate, stats, simulate, find, search, and repl:
+  -lib dir                     directory of tables to load (default $TABLES_LIBRARY)
+  -file path                   table file to load, may be repeated
+`
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+//run executes the command in args, returning the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "roll":
+		err = roll(args[1:], stdout)
+	case "show":
+		err = show(args[1:], stdout)
+	case "validate":
+		err = validate(args[1:], stdout)
+	case "stats":
+		err = stats(args[1:], stdout)
+	case "simulate":
+		err = simulate(args[1:], stdout)
+	case "find":
+		err = find(args[1:], stdout)
+	case "search":
+		err = search(args[1:], stdout)
+	case "convert":
+		err = convert(args[1:], stdout)
+	case "repl":
+		err = repl(args[1:], stdout)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		err = errUsage
+	}
+
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "tables: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+//libraryFlags adds the flags used to load a library to fs, the returned function loads it once fs is parsed.
+func libraryFlags(fs *flag.FlagSet) func() (*tables.Library, error) {
+	dir := fs.String("lib", os.Getenv("TABLES_LIBRARY"), "directory of tables to load")
+	var files stringList
+	fs.Var(&files, "file", "table file to load, may be repeated")
+
+	return func() (*tables.Library, error) {
+		library := tables.NewLibrary()
+		if *dir != "" {
+			var err error
+			library, err = tables.LoadLibrary(*dir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, file := range files {
+			table, err := tables.LoadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			library.Add(table)
+		}
+
+		if len(library.Names()) == 0 {
+			return nil, errors.New("no tables loaded, use -lib or -file")
+		}
+
+		return library, nil
+	}
+}
+
+func roll(args []string, stdout io.Writer) error {
+	fs := newFlagSet("roll")
+	load := libraryFlags(fs)
+	if fs.Parse(args) != nil || fs.NArg() == 0 {
+		return errUsage
+	}
+
+	library, err := load()
+	if err != nil {
+		return err
+	}
+
+	for i, expression := range fs.Args() {
+		records, err := library.Expression(expression)
+		if err != nil {
+			return fmt.Errorf("%s: %w", expression, err)
+		}
+
+		table, _ := library.Resolve(tables.ParseTablename(expression))
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		err = writeRecords(stdout, displayName(table), records)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func show(args []string, stdout io.Writer) error {
+	fs := newFlagSet("show")
+	load := libraryFlags(fs)
+	format := fs.String("format", "text", "output format")
+	if fs.Parse(args) != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+
+	library, err := load()
+	if err != nil {
+		return err
+	}
+
+	table, err := library.Resolve(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	if *format == "text" {
+		return writeRecords(stdout, displayName(table), table.Records())
+	}
+
+	f, ok := parseFormat(*format)
+	if !ok {
+		return fmt.Errorf("%s: %w", *format, tables.ErrUnsupportedFormat)
+	}
+
+	return tables.Encode(stdout, f, table)
+}
+
+func validate(args []string, stdout io.Writer) error {
+	fs := newFlagSet("validate")
+	load := libraryFlags(fs)
+	if fs.Parse(args) != nil {
+		return errUsage
+	}
+
+	library, err := load()
+	if err != nil {
+		return err
+	}
+
+	names := fs.Args()
+	if len(names) == 0 {
+		names = library.Names()
+	}
+
+	invalid := 0
+	for _, name := range names {
+		table, err := library.Resolve(name)
+		if err == nil {
+			err = table.Validate()
+		}
+		if err != nil {
+			invalid++
+			fmt.Fprintf(stdout, "%s: %s\n", name, err)
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: ok\n", name)
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d tables are invalid", invalid, len(names))
+	}
+
+	return nil
+}
+
+func stats(args []string, stdout io.Writer) error {
+	fs := newFlagSet("stats")
+	load := libraryFlags(fs)
+	if fs.Parse(args) != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+
+	library, err := load()
+	if err != nil {
+		return err
+	}
+
+	table, err := library.Resolve(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	probabilities, err := table.Probabilities()
+	if err != nil {
+		return fmt.Errorf("%s: %w", table.Meta.RollExpression, err)
+	}
+
+	title := displayName(table)
+	if table.Meta.RollableTable {
+		title = fmt.Sprintf("%s (%s)", title, table.Meta.RollExpression)
+	}
+	records := [][]string{{"Roll", "Probability", "Result"}}
+	for i, row := range table.Rows {
+		roll := tables.RowKey(row)
+		result := strings.Join(row.Results, ", ")
+		if table.Meta.RollableTable && len(row.Results) > 0 {
+			result = strings.Join(row.Results[1:], ", ")
+		}
+		records = append(records, []string{roll, fmt.Sprintf("%.2f%%", probabilities[i]*100), result})
+	}
+	return writeRecords(stdout, title, records)
+}
+
+func simulate(args []string, stdout io.Writer) error {
+	fs := newFlagSet("simulate")
+	load := libraryFlags(fs)
+	runs := fs.Int("n", 1000, "number of runs")
+	seed := fs.Int64("seed", 0, "seed for repeatable runs, 0 uses the dice package")
+	if fs.Parse(args) != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+
+	library, err := load()
+	if err != nil {
+		return err
+	}
+
+	var rand tables.Rand
+	if *seed != 0 {
+		rand = tables.NewRand(*seed)
+	}
+
+	simulation, err := library.Simulate(rand, fs.Arg(0), *runs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	return simulation.Report(stdout)
+}
+
+func find(args []string, stdout io.Writer) error {
+	fs := newFlagSet("find")
+	load := libraryFlags(fs)
+	if fs.Parse(args) != nil || fs.NArg() != 2 {
+		return errUsage
+	}
+
+	library, err := load()
+	if err != nil {
+		return err
+	}
+
+	table, err := library.Resolve(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	found, err := table.Find(fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(1), err)
+	}
+	if len(found) == 0 {
+		fmt.Fprintf(stdout, "no rows of %s match %q\n", table.Meta.Name, fs.Arg(1))
+		return nil
+	}
+
+	records := [][]string{{"Roll", "Probability", "Result"}}
+	for _, row := range found {
+		result := row.Results
+		if table.Meta.RollableTable && len(result) > 0 {
+			result = result[1:]
+		}
+		probability := "unknown"
+		if row.ProbabilityKnown {
+			probability = fmt.Sprintf("%.2f%%", row.Probability*100)
+		}
+		records = append(records, []string{tables.RowKey(row.Row), probability, strings.Join(result, ", ")})
+	}
+	return writeRecords(stdout, displayName(table), records)
+}
+
+func search(args []string, stdout io.Writer) error {
+	fs := newFlagSet("search")
+	load := libraryFlags(fs)
+	var campaigns stringList
+	fs.Var(&campaigns, "campaign", "only search tables in campaign, may be repeated")
+	limit := fs.Int("n", 0, "maximum number of results, 0 for all")
+	if fs.Parse(args) != nil || fs.NArg() == 0 {
+		return errUsage
+	}
+
+	library, err := load()
+	if err != nil {
+		return err
+	}
+
+	query := strings.Join(fs.Args(), " ")
+	results := library.Search(query, tables.SearchOptions{Campaigns: campaigns, Limit: *limit})
+	if len(results) == 0 {
+		fmt.Fprintf(stdout, "no tables match %q\n", query)
+		return nil
+	}
+
+	records := [][]string{{"Table", "Score", "Matched"}}
+	for _, result := range results {
+		fields := make([]string, len(result.Fields))
+		for i, field := range result.Fields {
+			fields[i] = string(field)
+		}
+		records = append(records, []string{result.Name, fmt.Sprintf("%.2f", result.Score), strings.Join(fields, ", ")})
+	}
+
+	return tables.Render(stdout, tables.RenderText, records, 0)
+}
+
+func convert(args []string, stdout io.Writer) error {
+	fs := newFlagSet("convert")
+	to := fs.String("to", "", "output format, defaults to the format of -o")
+	output := fs.String("o", "", "output file, defaults to stdout")
+	if fs.Parse(args) != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+
+	table, err := tables.LoadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	format, ok := parseFormat(*to)
+	if *to == "" {
+		format, ok = tables.FormatFromPath(*output)
+	}
+	if !ok {
+		return fmt.Errorf("output format %q: %w", *to, tables.ErrUnsupportedFormat)
+	}
+
+	if *output == "" {
+		return tables.Encode(stdout, format, table)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+
+	err = tables.Encode(f, format, table)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+//writeRecords writes a title followed by the records in aligned columns, the first record is the header.
+func writeRecords(w io.Writer, title string, records [][]string) error {
+	_, err := fmt.Fprintln(w, title)
+	if err != nil {
+		return err
+	}
+
+	return tables.Render(w, tables.RenderText, records, 0)
+}
+
+func displayName(table tables.Table) string {
+	if table.Meta.DisplayName != "" {
+		return table.Meta.DisplayName
+	}
+
+	return table.Meta.Name
+}
+
+func parseFormat(name string) (tables.Format, bool) {
+	switch strings.ToLower(name) {
+	case "csv":
+		return tables.FormatCSV, true
+	case "json":
+		return tables.FormatJSON, true
+	case "markdown", "md":
+		return tables.FormatMarkdown, true
+	case "yaml", "yml":
+		return tables.FormatYAML, true
+	}
+
+	return "", false
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	return fs
+}
+
+//stringList is a flag that can be repeated to provide multiple values (e.g. files).
+type stringList []string
+
+func (f *stringList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
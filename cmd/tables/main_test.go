@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeLibrary(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"weather.csv":   "D6,Weather\n1-3,Sunny\n4-5,Rain\n6,Storm\n",
+		"abilities.csv": "Ability,Description\nFUN,Funness\nBTR,Bitterness\n",
+		"broken.csv":    "D4,Result\n1-3,Low\n3-4,High\n",
+	}
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+	}
+
+	return dir
+}
+
+func Test_run(t *testing.T) {
+	dir := writeLibrary(t)
+
+	testCases := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{
+			name:     "validate a specific row is rolled",
+			args:     []string{"roll", "-lib", dir, "5#weather"},
+			wantCode: 0,
+			wantOut:  "weather\nD6   Weather\n4-5  Rain\n",
+		},
+		{
+			name:     "validate tables can be loaded from files",
+			args:     []string{"roll", "-file", filepath.Join(dir, "weather.csv"), "6#weather", "1#weather"},
+			wantCode: 0,
+			wantOut:  "weather\nD6  Weather\n6   Storm\n\nweather\nD6   Weather\n1-3  Sunny\n",
+		},
+		{
+			name:     "validate a table is shown",
+			args:     []string{"show", "-lib", dir, "abilities"},
+			wantCode: 0,
+			wantOut:  "abilities\nAbility  Description\nFUN      Funness\nBTR      Bitterness\n",
+		},
+		{
+			name:     "validate a table is shown in another format",
+			args:     []string{"show", "-lib", dir, "-format", "csv", "abilities"},
+			wantCode: 0,
+			wantOut:  "Ability,Description\nFUN,Funness\nBTR,Bitterness\n",
+		},
+		{
+			name:     "validate row probabilities are shown",
+			args:     []string{"stats", "-lib", dir, "weather"},
+			wantCode: 0,
+			wantOut:  "weather (d6)\nRoll  Probability  Result\n1-3   50.00%       Sunny\n4-5   33.33%       Rain\n6     16.67%       Storm\n",
+		},
+		{
+			name:     "validate a table expression is simulated",
+			args:     []string{"simulate", "-lib", dir, "-n", "10", "6#weather"},
+			wantCode: 0,
+			wantOut:  "10 runs\n\nWeather\nStorm  10  100.00%\n",
+		},
+		{
+			name:     "validate tables are validated",
+			args:     []string{"validate", "-lib", dir, "weather", "abilities"},
+			wantCode: 0,
+			wantOut:  "weather: ok\nabilities: ok\n",
+		},
+		{
+			name:     "validate invalid tables are reported",
+			args:     []string{"validate", "-lib", dir},
+			wantCode: 1,
+			wantOut:  "abilities: ok\nbroken: roll overlaps the roll of another row\nweather: ok\n",
+		},
+		{
+			name:     "validate a table is converted",
+			args:     []string{"convert", "-to", "md", filepath.Join(dir, "weather.csv")},
+			wantCode: 0,
+			wantOut:  "# weather\n\n| D6 | Weather |\n| --- | --- |\n| 1-3 | Sunny |\n| 4-5 | Rain |\n| 6 | Storm |\n",
+		},
+		{
+			name:     "validate an error is returned for a table that is not in the library",
+			args:     []string{"roll", "-lib", dir, "2?nope"},
+			wantCode: 1,
+		},
+		{
+			name:     "validate rows are found",
+			args:     []string{"find", "-lib", dir, "weather", "/^(Rain|Storm)$/"},
+			wantCode: 0,
+			wantOut:  "weather\nRoll  Probability  Result\n4-5   33.33%       Rain\n6     16.67%       Storm\n",
+		},
+		{
+			name:     "validate an error is returned for an invalid find query",
+			args:     []string{"find", "-lib", dir, "weather", "/(/"},
+			wantCode: 1,
+		},
+		{
+			name:     "validate tables are searched",
+			args:     []string{"search", "-lib", dir, "storm"},
+			wantCode: 0,
+			wantOut:  "Table    Score  Matched\nweather  1.00   rows\n",
+		},
+		{
+			name:     "validate a search without results",
+			args:     []string{"search", "-lib", dir, "dragon"},
+			wantCode: 0,
+			wantOut:  "no tables match \"dragon\"\n",
+		},
+		{
+			name:     "validate an error is returned when no tables are loaded",
+			args:     []string{"roll", "2?weather"},
+			wantCode: 1,
+		},
+		{
+			name:     "validate an error is returned for simulating without runs",
+			args:     []string{"simulate", "-lib", dir, "-n", "0", "2?weather"},
+			wantCode: 1,
+		},
+		{
+			name:     "validate usage is returned for an unknown command",
+			args:     []string{"juggle"},
+			wantCode: 2,
+		},
+		{
+			name:     "validate usage is returned for missing arguments",
+			args:     []string{"stats", "-lib", dir},
+			wantCode: 2,
+		},
+	}
+
+	t.Setenv("TABLES_LIBRARY", "")
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			got := run(test.args, &stdout, &stderr)
+
+			if got != test.wantCode {
+				t.Errorf("want %d, got %d (%s)", test.wantCode, got, stderr.String())
+			}
+			if test.wantOut != "" && stdout.String() != test.wantOut {
+				t.Errorf("want %q, got %q", test.wantOut, stdout.String())
+			}
+		})
+	}
+
+	t.Run("validate a table is converted to a file", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "weather.yaml")
+		var stdout, stderr bytes.Buffer
+		got := run([]string{"convert", "-o", output, filepath.Join(dir, "weather.csv")}, &stdout, &stderr)
+		if got != 0 {
+			t.Errorf("want 0, got %d (%s)", got, stderr.String())
+		}
+
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if !strings.Contains(string(data), "roll_expression: d6") {
+			t.Errorf("expected yaml table, got %s", data)
+		}
+	})
+
+	t.Run("validate random rows are rolled", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		got := run([]string{"roll", "-lib", dir, "uni:3?weather"}, &stdout, &stderr)
+		if got != 0 {
+			t.Errorf("want 0, got %d (%s)", got, stderr.String())
+		}
+
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		if len(lines) != 5 {
+			t.Errorf("want 5, got %d", len(lines))
+		}
+	})
+	t.Run("validate an error writing a table is returned", func(t *testing.T) {
+		var stderr bytes.Buffer
+		got := run([]string{"show", "-lib", dir, "weather"}, failingWriter{}, &stderr)
+		if got != 1 {
+			t.Errorf("want 1, got %d", got)
+		}
+		if !strings.Contains(stderr.String(), errWrite.Error()) {
+			t.Errorf("want %s, got %s", errWrite, stderr.String())
+		}
+	})
+}
+
+var errWrite = errors.New("disk full")
+
+//failingWriter fails every write with errWrite.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errWrite
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fantastical-world/tables"
+	"golang.org/x/term"
+)
+
+const replHelp = `type a table expression to roll it (e.g. 2?npc_names, uni:3?rumors, 5#weather)
+
+commands:
+  tables          list the loaded tables
+  seed <n>        seed every roll that follows, so they can be repeated
+  seed off        stop seeding rolls
+  history         list the lines entered this session
+  help            show this help
+  exit            leave (or ctrl-d)
+
+press tab to complete table names, and up or down to move through history
+`
+
+//session holds the state of a repl session and evaluates the lines entered into it.
+type session struct {
+	library *tables.Library
+	rand    tables.Rand
+	history []string
+	out     io.Writer
+}
+
+func repl(args []string, stdout io.Writer) error {
+	fs := newFlagSet("repl")
+	load := libraryFlags(fs)
+	if fs.Parse(args) != nil || fs.NArg() != 0 {
+		return errUsage
+	}
+
+	library, err := load()
+	if err != nil {
+		return err
+	}
+
+	s := &session{library: library, out: stdout}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if !s.eval(scanner.Text()) {
+				break
+			}
+		}
+		return scanner.Err()
+	}
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return err
+	}
+	defer term.Restore(int(os.Stdin.Fd()), state)
+
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, stdout}, "> ")
+	terminal.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		return complete(line, pos, library.Names())
+	}
+
+	//the terminal is in raw mode, so output must go through it to get line endings right
+	s.out = terminal
+	fmt.Fprintf(terminal, "%d tables loaded, type help for help\n", len(library.Names()))
+	for {
+		line, err := terminal.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !s.eval(line) {
+			return nil
+		}
+	}
+}
+
+//eval evaluates a single line, returning false if the session should end.
+func (s *session) eval(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return true
+	}
+	s.history = append(s.history, line)
+
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case "exit", "quit":
+		return false
+	case "help":
+		fmt.Fprint(s.out, replHelp)
+	case "tables":
+		for _, name := range s.library.Names() {
+			table, _ := s.library.Table(name)
+			fmt.Fprintf(s.out, "%s\t%s\n", name, displayName(table))
+		}
+	case "history":
+		for i, entry := range s.history {
+			fmt.Fprintf(s.out, "%4d  %s\n", i+1, entry)
+		}
+	case "seed":
+		s.seed(fields[1:])
+	default:
+		s.roll(line)
+	}
+
+	return true
+}
+
+func (s *session) seed(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(s.out, "usage: seed <n> or seed off")
+		return
+	}
+
+	if args[0] == "off" {
+		s.rand = nil
+		fmt.Fprintln(s.out, "rolls are no longer seeded")
+		return
+	}
+
+	seed, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintf(s.out, "seed must be a number, got %s\n", args[0])
+		return
+	}
+	s.rand = tables.NewRand(seed)
+	fmt.Fprintf(s.out, "rolls are seeded with %d\n", seed)
+}
+
+func (s *session) roll(expression string) {
+	records, err := s.library.ExpressionWith(s.rand, expression)
+	if err != nil {
+		fmt.Fprintf(s.out, "%s: %s\n", expression, err)
+		return
+	}
+
+	table, _ := s.library.Resolve(tables.ParseTablename(expression))
+	err = writeRecords(s.out, displayName(table), records)
+	if err != nil {
+		fmt.Fprintf(s.out, "%s: %s\n", expression, err)
+	}
+}
+
+//complete completes the word at pos in line, table names are completed after the ? or # of a table expression and
+//commands are completed at the start of the line. If more than one name matches, their common prefix is completed.
+func complete(line string, pos int, names []string) (string, int, bool) {
+	start := strings.LastIndexAny(line[:pos], " ?#") + 1
+	word := line[start:pos]
+
+	candidates := names
+	if start == 0 {
+		candidates = []string{"exit", "help", "history", "seed", "tables"}
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+	sort.Strings(matches)
+
+	completion := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, completion) {
+			completion = completion[:len(completion)-1]
+		}
+	}
+
+	return line[:start] + completion + line[pos:], start + len(completion), true
+}
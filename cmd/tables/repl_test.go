@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fantastical-world/tables"
+)
+
+func newTestSession(t *testing.T) (*session, *bytes.Buffer) {
+	weather, err := tables.Load([][]string{{"D6", "Weather"}, {"1-3", "Sunny"}, {"4-5", "Rain"}, {"6", "Storm, {{1d1}} bolt"}}, "weather", "Today's Weather", "d6")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	var out bytes.Buffer
+	return &session{library: tables.NewLibrary(weather), out: &out}, &out
+}
+
+func Test_session_eval(t *testing.T) {
+	testCases := []struct {
+		name string
+		line string
+		want string
+	}{
+		{
+			name: "validate a table expression is rolled and printed",
+			line: "6#weather",
+			want: "Today's Weather\nD6  Weather\n6   Storm, 1 bolt\n",
+		},
+		{
+			name: "validate errors are printed",
+			line: "2?nope",
+			want: "2?nope: table does not exist\n",
+		},
+		{
+			name: "validate tables are listed",
+			line: "tables",
+			want: "weather\tToday's Weather\n",
+		},
+		{
+			name: "validate an invalid seed is reported",
+			line: "seed twelve",
+			want: "seed must be a number, got twelve\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			s, out := newTestSession(t)
+
+			if !s.eval(test.line) {
+				t.Errorf("expected session to continue")
+			}
+			if out.String() != test.want {
+				t.Errorf("want %q, got %q", test.want, out.String())
+			}
+		})
+	}
+
+	t.Run("validate seeded rolls are repeated", func(t *testing.T) {
+		s, out := newTestSession(t)
+
+		s.eval("seed 99")
+		out.Reset()
+		s.eval("10?weather")
+		want := out.String()
+
+		s.eval("seed 99")
+		out.Reset()
+		s.eval("10?weather")
+		if out.String() != want {
+			t.Errorf("want %q, got %q", want, out.String())
+		}
+	})
+
+	t.Run("validate history is listed", func(t *testing.T) {
+		s, out := newTestSession(t)
+
+		s.eval("1#weather")
+		s.eval("  ")
+		out.Reset()
+		s.eval("history")
+		want := "   1  1#weather\n   2  history\n"
+		if out.String() != want {
+			t.Errorf("want %q, got %q", want, out.String())
+		}
+	})
+
+	t.Run("validate the session ends on exit", func(t *testing.T) {
+		s, _ := newTestSession(t)
+
+		if s.eval("exit") {
+			t.Errorf("expected session to end")
+		}
+	})
+}
+
+func Test_complete(t *testing.T) {
+	names := []string{"npc_names", "npc_traits", "rumors", "weather"}
+	testCases := []struct {
+		name    string
+		line    string
+		pos     int
+		want    string
+		wantPos int
+		wantOK  bool
+	}{
+		{name: "validate a table name is completed", line: "2?wea", pos: 5, want: "2?weather", wantPos: 9, wantOK: true},
+		{name: "validate a unique table name is completed", line: "uni:3?ru", pos: 8, want: "uni:3?rumors", wantPos: 12, wantOK: true},
+		{name: "validate the common prefix of table names is completed", line: "?np", pos: 3, want: "?npc_", wantPos: 5, wantOK: true},
+		{name: "validate a specific row table name is completed", line: "5#w", pos: 3, want: "5#weather", wantPos: 9, wantOK: true},
+		{name: "validate commands are completed", line: "se", pos: 2, want: "seed", wantPos: 4, wantOK: true},
+		{name: "validate text after the cursor is kept", line: "?ru 1", pos: 3, want: "?rumors 1", wantPos: 7, wantOK: true},
+		{name: "validate nothing is completed without a match", line: "?dragons", pos: 8, wantOK: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotPos, ok := complete(test.line, test.pos, names)
+
+			if ok != test.wantOK {
+				t.Errorf("want %t, got %t", test.wantOK, ok)
+			}
+			if ok && (got != test.want || gotPos != test.wantPos) {
+				t.Errorf("want %s at %d, got %s at %d", test.want, test.wantPos, got, gotPos)
+			}
+		})
+	}
+}
+
+func Test_run_repl(t *testing.T) {
+	t.Run("validate usage is returned for unexpected arguments", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		got := run([]string{"repl", "-lib", writeLibrary(t), "extra"}, &stdout, &stderr)
+		if got != 2 {
+			t.Errorf("want 2, got %d", got)
+		}
+		if !strings.HasPrefix(stderr.String(), "usage:") {
+			t.Errorf("expected usage, got %s", stderr.String())
+		}
+	})
+}
@@ -0,0 +1,46 @@
+//Command tablesd serves a library of tables over HTTP, see tables.NewHandler for the API. Use -audience player to
+//serve a player-safe view of the tables (see tables.View).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/fantastical-world/tables"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dir := flag.String("lib", os.Getenv("TABLES_LIBRARY"), "directory of tables to serve")
+	audience := flag.String("audience", string(tables.AudienceGM), "audience tables are shown to (gm or player), columns hidden from it are not served")
+	flag.Parse()
+
+	switch tables.Audience(*audience) {
+	case tables.AudienceGM, tables.AudiencePlayer:
+	default:
+		fmt.Fprintf(flag.CommandLine.Output(), "tablesd: unknown audience %q, use %s or %s\n", *audience, tables.AudienceGM, tables.AudiencePlayer)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	library := tables.NewLibrary()
+	if *dir != "" {
+		var err error
+		library, err = tables.LoadLibrary(*dir)
+		if err != nil {
+			log.Fatalf("tablesd: %s", err)
+		}
+	}
+	log.Printf("tablesd: serving %d tables on %s", len(library.Names()), *addr)
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           tables.NewHandlerFor(library, tables.Audience(*audience)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Fatalf("tablesd: %s", server.ListenAndServe())
+}
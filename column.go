@@ -0,0 +1,407 @@
+package tables
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fantastical-world/dice"
+)
+
+const ErrInvalidColumnType = TableError("not a valid column type")
+const ErrInvalidCellValue = TableError("cell value does not match the type of its column")
+const ErrMixedCurrencies = TableError("values are not in the same currency")
+
+//ColumnType is the type of the values in a column. Enums list their values after the type (e.g. enum:small|large),
+//values name their unit system (e.g. value:coins, see RegisterUnitSystem), and an empty type is the same as text.
+type ColumnType string
+
+const (
+	ColumnText     ColumnType = "text"
+	ColumnInt      ColumnType = "int"
+	ColumnDecimal  ColumnType = "decimal"
+	ColumnCurrency ColumnType = "currency"
+	ColumnDice     ColumnType = "dice"
+	ColumnTable    ColumnType = "table"
+	ColumnEnum     ColumnType = "enum"
+	ColumnValue    ColumnType = "value"
+)
+
+var (
+	currencyRE   = regexp.MustCompile(`^([+-]?\d[\d,]*(?:\.\d+)?)\s*([a-zA-Z]*)$`)
+	diceColumnRE = regexp.MustCompile(`^(\S+?)\s*(?:[x*]\s*(\d+))?$`)
+)
+
+//Value is a rolled cell read using the type of its column.
+type Value struct {
+	Type ColumnType
+	Text string
+}
+
+//ParseColumnType returns the column type named by value, or false if it is not a column type.
+func ParseColumnType(value string) (ColumnType, bool) {
+	columnType := ColumnType(strings.TrimSpace(value))
+	switch columnType.Kind() {
+	case ColumnText, ColumnInt, ColumnDecimal, ColumnCurrency, ColumnDice, ColumnTable:
+		return columnType, columnType == columnType.Kind()
+	case ColumnEnum:
+		return columnType, len(columnType.Options()) > 0
+	case ColumnValue:
+		_, err := columnType.UnitSystem()
+		return columnType, err == nil
+	}
+
+	return "", false
+}
+
+//Kind returns the type without the values of an enum, an empty type is text.
+func (c ColumnType) Kind() ColumnType {
+	if c == "" {
+		return ColumnText
+	}
+	kind, _, _ := strings.Cut(string(c), ":")
+
+	return ColumnType(kind)
+}
+
+//Options returns the values of an enum.
+func (c ColumnType) Options() []string {
+	_, options, ok := strings.Cut(string(c), ":")
+	if c.Kind() != ColumnEnum || !ok || options == "" {
+		return nil
+	}
+
+	return strings.Split(options, "|")
+}
+
+//UnitSystem returns the unit system of a value column.
+func (c ColumnType) UnitSystem() (UnitSystem, error) {
+	name, ok := strings.CutPrefix(string(c), string(ColumnValue)+":")
+	if c.Kind() != ColumnValue || !ok {
+		return UnitSystem{}, ErrInvalidColumnType
+	}
+
+	return LookupUnitSystem(name)
+}
+
+//Check returns ErrInvalidCellValue if value is not a value of the type. Roll expressions and captures in value
+//(e.g. {{2d6}}0 gp) are checked as if they rolled a 1.
+func (c ColumnType) Check(value string) error {
+	value = dice.ContainsRollExpressionBracedRE.ReplaceAllString(value, "1")
+	value = RowCaptureRE.ReplaceAllString(value, "1")
+	value = RowReferenceRE.ReplaceAllString(value, "1")
+
+	_, err := Value{Type: c, Text: value}.check()
+	return err
+}
+
+//Int returns the value of an int column.
+func (v Value) Int() (int, error) {
+	if v.Type.Kind() != ColumnInt {
+		return 0, ErrInvalidColumnType
+	}
+
+	number, err := strconv.Atoi(strings.TrimSpace(v.Text))
+	if err != nil {
+		return 0, fmt.Errorf("%w, %q is not an int", ErrInvalidCellValue, v.Text)
+	}
+
+	return number, nil
+}
+
+//Decimal returns the value of an int, decimal, currency, or value column as a number. Currency units are dropped, and
+//values are in the base unit of their unit system (see Amount).
+func (v Value) Decimal() (float64, error) {
+	switch v.Type.Kind() {
+	case ColumnValue:
+		return v.Amount()
+	case ColumnInt:
+		number, err := v.Int()
+		return float64(number), err
+	case ColumnCurrency:
+		amount, _, err := v.Currency()
+		return amount, err
+	case ColumnDecimal:
+		number, err := strconv.ParseFloat(strings.TrimSpace(v.Text), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w, %q is not a decimal", ErrInvalidCellValue, v.Text)
+		}
+		return number, nil
+	}
+
+	return 0, ErrInvalidColumnType
+}
+
+//Currency returns the amount and unit (e.g. 250 and gp) of a currency column, the unit is empty if there isn't one.
+func (v Value) Currency() (float64, string, error) {
+	if v.Type.Kind() != ColumnCurrency {
+		return 0, "", ErrInvalidColumnType
+	}
+
+	match := currencyRE.FindStringSubmatch(strings.TrimSpace(v.Text))
+	if match == nil {
+		return 0, "", fmt.Errorf("%w, %q is not an amount of currency", ErrInvalidCellValue, v.Text)
+	}
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("%w, %q is not an amount of currency", ErrInvalidCellValue, v.Text)
+	}
+
+	return amount, match[2], nil
+}
+
+//Amount returns the amount of a value column in the base unit of its unit system (e.g. 2 gp, 5 sp is 250 coppers).
+func (v Value) Amount() (float64, error) {
+	system, err := v.Type.UnitSystem()
+	if err != nil {
+		return 0, err
+	}
+
+	return system.Parse(v.Text)
+}
+
+//Roll rolls the value of a dice column using r. Dice can be multiplied (e.g. 2d6 x 10). A nil r uses the dice package.
+func (v Value) Roll(r Rand) (int, error) {
+	expression, multiplier, err := v.dice()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := rollWith(r, expression)
+	if err != nil {
+		return 0, err
+	}
+
+	return result * multiplier, nil
+}
+
+//Reference returns the table expression of a table column, a table name is returned as an expression rolling the
+//table once (e.g. gems returns ?gems).
+func (v Value) Reference() (string, error) {
+	if v.Type.Kind() != ColumnTable {
+		return "", ErrInvalidColumnType
+	}
+
+	te := strings.TrimSpace(v.Text)
+	if !strings.ContainsAny(te, "?#") {
+		te = "?" + te
+	}
+	if !TableRollExpressionRE.MatchString(strings.TrimPrefix(te, "uni:")) {
+		return "", fmt.Errorf("%w, %q is not a table expression", ErrInvalidCellValue, v.Text)
+	}
+
+	return te, nil
+}
+
+//check returns an error if the value does not match its type.
+func (v Value) check() (Value, error) {
+	var err error
+	switch v.Type.Kind() {
+	case ColumnText:
+	case ColumnInt:
+		_, err = v.Int()
+	case ColumnDecimal, ColumnCurrency, ColumnValue:
+		_, err = v.Decimal()
+	case ColumnDice:
+		_, _, err = v.dice()
+	case ColumnTable:
+		_, err = v.Reference()
+	case ColumnEnum:
+		err = fmt.Errorf("%w, %q is not one of %s", ErrInvalidCellValue, v.Text, strings.Join(v.Type.Options(), ", "))
+		for _, option := range v.Type.Options() {
+			if v.Text == option {
+				err = nil
+				break
+			}
+		}
+	default:
+		err = ErrInvalidColumnType
+	}
+
+	return v, err
+}
+
+//dice returns the roll expression and multiplier of a dice column.
+func (v Value) dice() (string, int, error) {
+	if v.Type.Kind() != ColumnDice {
+		return "", 0, ErrInvalidColumnType
+	}
+
+	match := diceColumnRE.FindStringSubmatch(strings.ToLower(strings.TrimSpace(v.Text)))
+	if match == nil || !dice.ValidRollExpression(match[1]) {
+		return "", 0, fmt.Errorf("%w, %q is not a roll expression", ErrInvalidCellValue, v.Text)
+	}
+
+	multiplier := 1
+	if match[2] != "" {
+		multiplier, _ = strconv.Atoi(match[2])
+	}
+
+	return match[1], multiplier, nil
+}
+
+//ColumnType returns the type of a column, text if the table does not have column types.
+func (t Table) ColumnType(column int) ColumnType {
+	if column < 0 || column >= len(t.Meta.ColumnTypes) || t.Meta.ColumnTypes[column] == "" {
+		return ColumnText
+	}
+
+	return t.Meta.ColumnTypes[column]
+}
+
+//ColumnIndex returns the index of the column with the provided header, headers are matched exactly before ignoring case.
+func (t Table) ColumnIndex(header string) (int, error) {
+	for _, equal := range []func(a, b string) bool{func(a, b string) bool { return a == b }, strings.EqualFold} {
+		for i, h := range t.Meta.Headers {
+			if equal(h, header) {
+				return i, nil
+			}
+		}
+	}
+
+	return -1, ErrColumnDoesNotExist
+}
+
+//Value returns the cell of a rolled row (e.g. from GetRow) in the column with the provided header, using the
+//column's type. ErrInvalidCellValue is returned if the cell does not match the type.
+func (t Table) Value(result []string, header string) (Value, error) {
+	column, err := t.ColumnIndex(header)
+	if err != nil {
+		return Value{}, err
+	}
+	if column >= len(result) {
+		return Value{}, ErrInvalidColumnCount
+	}
+
+	return Value{Type: t.ColumnType(column), Text: result[column]}.check()
+}
+
+//Sum returns the sum of an int, decimal, currency, or value column of records returned by Expression, the first record
+//is the header and is skipped. Values are summed in the base unit of their unit system, which can format the sum (see
+//UnitSystem.Format). ErrMixedCurrencies is returned if the currency values do not all have the same unit.
+func (t Table) Sum(records [][]string, header string) (float64, error) {
+	sum := 0.0
+	unit := ""
+	for i := 1; i < len(records); i++ {
+		value, err := t.Value(records[i], header)
+		if err != nil {
+			return 0, err
+		}
+
+		if value.Type.Kind() == ColumnCurrency {
+			_, currency, _ := value.Currency()
+			if i > 1 && currency != unit {
+				return 0, ErrMixedCurrencies
+			}
+			unit = currency
+		}
+
+		number, err := value.Decimal()
+		if err != nil {
+			return 0, err
+		}
+		sum += number
+	}
+
+	return sum, nil
+}
+
+//checkColumnTypes returns an error if the table's column types are not valid, or a cell does not match the type of its
+//column. The roll column of a rollable table is not checked.
+func (t Table) checkColumnTypes() error {
+	if len(t.Meta.ColumnTypes) == 0 {
+		return nil
+	}
+	if len(t.Meta.ColumnTypes) != t.Meta.ColumnCount {
+		return ErrInvalidColumnCount
+	}
+
+	for i, columnType := range t.Meta.ColumnTypes {
+		if _, ok := ParseColumnType(string(columnType)); !ok && columnType != "" {
+			return fmt.Errorf("%w, %q", ErrInvalidColumnType, columnType)
+		}
+		if i == 0 && t.Meta.RollableTable {
+			continue
+		}
+
+		for _, row := range t.Rows {
+			if i >= len(row.Results) {
+				return ErrInvalidColumnCount
+			}
+			err := columnType.Check(row.Results[i])
+			if err != nil {
+				return fmt.Errorf("%w in column %s", err, t.Meta.Headers[i])
+			}
+		}
+	}
+
+	return nil
+}
+
+//checkCells returns an error if a cell of record does not match the type of its column.
+func (t Table) checkCells(record []string) error {
+	for i, value := range record {
+		if i == 0 && t.Meta.RollableTable {
+			continue
+		}
+		err := t.ColumnType(i).Check(value)
+		if err != nil {
+			return fmt.Errorf("%w in column %s", err, t.Meta.Headers[i])
+		}
+	}
+
+	return nil
+}
+
+//splitHeaders removes type annotations (e.g. Cost:currency) from headers, returning the headers and their types. The
+//types are nil if no header is annotated, and a header ending in something that is not a type is left as it is.
+func splitHeaders(headers []string) ([]string, []ColumnType) {
+	names := make([]string, len(headers))
+	types := make([]ColumnType, len(headers))
+	annotated := false
+	for i, header := range headers {
+		names[i] = header
+		//annotations are never spaced (e.g. Note: text is a header, not a text column)
+		name, annotation, ok := strings.Cut(header, ":")
+		if !ok || annotation != strings.TrimSpace(annotation) {
+			continue
+		}
+		if columnType, ok := ParseColumnType(annotation); ok {
+			names[i] = strings.TrimSpace(name)
+			types[i] = columnType
+			annotated = true
+		}
+	}
+
+	if !annotated {
+		return headers, nil
+	}
+
+	return names, types
+}
+
+//annotatedRecords returns the table's records with row meta data (see RecordsWithMeta), the type of each typed column
+//added to its header, and GMColumnPrefix added to the header of columns only AudienceGM can see. The records can be
+//read back by Load.
+func (t Table) annotatedRecords() [][]string {
+	records := t.RecordsWithMeta()
+	if (len(t.Meta.ColumnTypes) == 0 && len(t.Meta.Visibility) == 0) || len(records) == 0 {
+		return records
+	}
+
+	headers := cloneStrings(records[0])
+	for i, columnType := range t.Meta.ColumnTypes {
+		if i < len(headers) && columnType != "" {
+			headers[i] = headers[i] + ":" + string(columnType)
+		}
+	}
+	for i, audience := range t.Meta.Visibility {
+		if i < len(headers) && audience == AudienceGM {
+			headers[i] = GMColumnPrefix + headers[i]
+		}
+	}
+	records[0] = headers
+
+	return records
+}
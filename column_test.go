@@ -0,0 +1,253 @@
+package tables
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var lootCSV = [][]string{
+	{"D4", "Item", "Cost:currency", "Weight:decimal", "Count:int", "Damage:dice", "Size:enum:small|large", "Extra:table"},
+	{"1", "Dagger", "2 gp", "1", "{{1d1}}", "1d4", "small", "gems"},
+	{"2", "Sword", "15 gp", "3", "1", "1d8", "large", "2?gems"},
+	{"3", "Coins", "{{2d6}}0 gp", "0.5", "10", "2d6 x 10", "small", "uni:2?gems"},
+	{"4", "Copper", "50 cp", "0.1", "5", "d6", "small", "1#gems"},
+}
+
+func testLootTable(t *testing.T) Table {
+	table, err := Load(lootCSV, "loot", "Loot", "d4")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	return table
+}
+
+func TestLoad_columnTypes(t *testing.T) {
+	t.Run("validate header annotations are read as column types", func(t *testing.T) {
+		table := testLootTable(t)
+
+		wantHeaders := []string{"D4", "Item", "Cost", "Weight", "Count", "Damage", "Size", "Extra"}
+		if !reflect.DeepEqual(wantHeaders, table.Meta.Headers) {
+			t.Errorf("want %v, got %v", wantHeaders, table.Meta.Headers)
+		}
+		wantTypes := []ColumnType{"", "", ColumnCurrency, ColumnDecimal, ColumnInt, ColumnDice, "enum:small|large", ColumnTable}
+		if !reflect.DeepEqual(wantTypes, table.Meta.ColumnTypes) {
+			t.Errorf("want %v, got %v", wantTypes, table.Meta.ColumnTypes)
+		}
+	})
+
+	t.Run("validate tables without annotations do not have column types", func(t *testing.T) {
+		table, _ := Load([][]string{{"D2", "Note: text"}, {"1", "a"}, {"2", "b"}}, "notes", "Notes", "d2")
+		if table.Meta.ColumnTypes != nil {
+			t.Errorf("want no column types, got %v", table.Meta.ColumnTypes)
+		}
+		if table.Meta.Headers[1] != "Note: text" {
+			t.Errorf("want Note: text, got %s", table.Meta.Headers[1])
+		}
+	})
+
+	t.Run("validate column types are kept when encoded and decoded", func(t *testing.T) {
+		table := testLootTable(t)
+		for _, format := range []Format{FormatCSV, FormatMarkdown, FormatJSON, FormatYAML} {
+			var b bytes.Buffer
+			err := Encode(&b, format, table)
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+
+			got, err := Decode(&b, format, "loot")
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+			if !reflect.DeepEqual(table.Meta.ColumnTypes, got.Meta.ColumnTypes) || !reflect.DeepEqual(table.Meta.Headers, got.Meta.Headers) {
+				t.Errorf("want %v %v, got %v %v (%s)", table.Meta.Headers, table.Meta.ColumnTypes, got.Meta.Headers, got.Meta.ColumnTypes, format)
+			}
+		}
+	})
+
+	testErrors := []struct {
+		name    string
+		records [][]string
+	}{
+		{name: "validate an error is returned for an int that is not a number", records: [][]string{{"Count:int"}, {"many"}}},
+		{name: "validate an error is returned for a decimal that is not a number", records: [][]string{{"Weight:decimal"}, {"heavy"}}},
+		{name: "validate an error is returned for currency without an amount", records: [][]string{{"Cost:currency"}, {"gp"}}},
+		{name: "validate an error is returned for dice that are not a roll expression", records: [][]string{{"Damage:dice"}, {"2x6"}}},
+		{name: "validate an error is returned for a value that is not in an enum", records: [][]string{{"Size:enum:small|large"}, {"medium"}}},
+		{name: "validate an error is returned for a reference that is not a table expression", records: [][]string{{"Extra:table"}, {"two gems"}}},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Load(test.records, "broken", "Broken", "")
+			if !errors.Is(err, ErrInvalidCellValue) {
+				t.Errorf("want %v, got %v", ErrInvalidCellValue, err)
+			}
+		})
+	}
+}
+
+func TestTable_columnTypes(t *testing.T) {
+	t.Run("validate edits are checked against column types", func(t *testing.T) {
+		table := testLootTable(t)
+
+		err := table.UpdateCell(0, 4, "a few")
+		if !errors.Is(err, ErrInvalidCellValue) {
+			t.Errorf("want %v, got %v", ErrInvalidCellValue, err)
+		}
+		err = table.AddRow([]string{"5", "Axe", "8 gp", "2", "1", "1d6", "medium", "gems"})
+		if !errors.Is(err, ErrInvalidCellValue) {
+			t.Errorf("want %v, got %v", ErrInvalidCellValue, err)
+		}
+		err = table.AddColumn("Rarity:enum:common|rare", "common")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got := table.ColumnType(8); got != "enum:common|rare" {
+			t.Errorf("want enum:common|rare, got %s", got)
+		}
+		if table.Meta.Headers[8] != "Rarity" {
+			t.Errorf("want Rarity, got %s", table.Meta.Headers[8])
+		}
+
+		err = table.Validate()
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+	})
+
+	t.Run("validate tables with invalid column types are not valid", func(t *testing.T) {
+		table := testLootTable(t)
+		table.Meta.ColumnTypes[1] = "colour"
+
+		err := table.Validate()
+		if !errors.Is(err, ErrInvalidColumnType) {
+			t.Errorf("want %v, got %v", ErrInvalidColumnType, err)
+		}
+	})
+}
+
+func TestTable_Value(t *testing.T) {
+	table := testLootTable(t)
+	row, err := table.GetRowWith(NewRand(1), 3)
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	t.Run("validate typed values are read from a rolled row", func(t *testing.T) {
+		cost, _ := table.Value(row, "cost")
+		amount, unit, err := cost.Currency()
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if amount < 20 || amount > 120 || int(amount)%10 != 0 || unit != "gp" {
+			t.Errorf("want 20 to 120 gp, got %v %s", amount, unit)
+		}
+
+		weight, _ := table.Value(row, "Weight")
+		if got, _ := weight.Decimal(); got != 0.5 {
+			t.Errorf("want 0.5, got %v", got)
+		}
+
+		count, _ := table.Value(row, "Count")
+		if got, _ := count.Int(); got != 10 {
+			t.Errorf("want 10, got %v", got)
+		}
+
+		damage, _ := table.Value(row, "Damage")
+		got, err := damage.Roll(NewRand(1))
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got < 20 || got > 120 || got%10 != 0 {
+			t.Errorf("want 20 to 120, got %d", got)
+		}
+
+		extra, _ := table.Value(row, "Extra")
+		if got, _ := extra.Reference(); got != "uni:2?gems" {
+			t.Errorf("want uni:2?gems, got %s", got)
+		}
+		extra, _ = table.Value(table.Rows[0].Results, "Extra")
+		if got, _ := extra.Reference(); got != "?gems" {
+			t.Errorf("want ?gems, got %s", got)
+		}
+	})
+
+	t.Run("validate values are summed across rows", func(t *testing.T) {
+		records := [][]string{table.Meta.Headers, table.Rows[0].Results, table.Rows[1].Results}
+
+		got, err := table.Sum(records, "Cost")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got != 17 {
+			t.Errorf("want 17, got %v", got)
+		}
+
+		got, err = table.Sum(records, "Weight")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got != 4 {
+			t.Errorf("want 4, got %v", got)
+		}
+	})
+
+	testErrors := []struct {
+		name   string
+		header string
+		get    func(v Value) error
+		want   error
+	}{
+		{name: "validate an error is returned for a column that does not exist", header: "Nope", want: ErrColumnDoesNotExist},
+		{name: "validate an error is returned for reading text as a number", header: "Item", get: func(v Value) error { _, err := v.Decimal(); return err }, want: ErrInvalidColumnType},
+		{name: "validate an error is returned for rolling a column that is not dice", header: "Count", get: func(v Value) error { _, err := v.Roll(nil); return err }, want: ErrInvalidColumnType},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := table.Value(row, test.header)
+			if err == nil {
+				err = test.get(value)
+			}
+			if !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+
+	t.Run("validate an error is returned for summing different currencies", func(t *testing.T) {
+		_, err := table.Sum([][]string{table.Meta.Headers, table.Rows[0].Results, table.Rows[3].Results}, "Cost")
+		if err != ErrMixedCurrencies {
+			t.Errorf("want %v, got %v", ErrMixedCurrencies, err)
+		}
+	})
+}
+
+func TestParseColumnType(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ColumnType
+		ok    bool
+	}{
+		{value: "int", want: ColumnInt, ok: true},
+		{value: " currency ", want: ColumnCurrency, ok: true},
+		{value: "enum:a|b", want: "enum:a|b", ok: true},
+		{value: "value:coins", want: "value:coins", ok: true},
+		{value: "value", ok: false},
+		{value: "enum", ok: false},
+		{value: "int:5", ok: false},
+		{value: "colour", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run("validate column type "+test.value, func(t *testing.T) {
+			got, ok := ParseColumnType(test.value)
+			if ok != test.ok || (ok && got != test.want) {
+				t.Errorf("want %s (%t), got %s (%t)", test.want, test.ok, got, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package tables
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//TableDiff describes the changes needed to turn one table into another.
+//Rows are matched using their roll (RollRange if the row has one, otherwise DieRoll), see RowKey.
+type TableDiff struct {
+	Meta    []MetaChange `json:"meta,omitempty"`
+	Added   []Row        `json:"added,omitempty"`
+	Removed []Row        `json:"removed,omitempty"`
+	Changed []RowChange  `json:"changed,omitempty"`
+}
+
+//MetaChange is a change to a single Meta field, the field is identified by its JSON name (e.g. display_name).
+//From and To hold the JSON encoded values of the field.
+type MetaChange struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from"`
+	To    json.RawMessage `json:"to"`
+}
+
+//RowChange is a row that exists in both tables but has different content.
+type RowChange struct {
+	Key  string `json:"key"`
+	From Row    `json:"from"`
+	To   Row    `json:"to"`
+}
+
+//Empty returns true if the diff contains no changes.
+func (d TableDiff) Empty() bool {
+	return len(d.Meta) == 0 && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+//ContentHash returns a SHA-256 hash of the table's meta data and rows.
+//Unlike Hash, which identifies a table, the content hash changes whenever the table is edited.
+func (t Table) ContentHash() string {
+	//struct fields are always marshaled in declaration order, and map keys are sorted, so this is canonical
+	b, _ := json.Marshal(t)
+
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+//RowKey returns the key used to match rows between tables, the roll range if the row has one, otherwise its die roll.
+func RowKey(row Row) string {
+	if row.RollRange != "" {
+		return row.RollRange
+	}
+
+	return strconv.Itoa(row.DieRoll)
+}
+
+//Diff returns the changes needed to turn table a into table b. Rows can only be matched if each row in a table has its
+//own key, ErrDuplicateRowKey is returned if two rows in either table have the same key.
+func Diff(a, b Table) (TableDiff, error) {
+	for _, rows := range [][]Row{a.Rows, b.Rows} {
+		if key, ok := duplicateRowKey(rows); ok {
+			return TableDiff{}, fmt.Errorf("%w, %s", ErrDuplicateRowKey, key)
+		}
+	}
+
+	diff := TableDiff{Meta: diffMeta(a.Meta, b.Meta)}
+	aRows := rowsByKey(a.Rows)
+	bRows := rowsByKey(b.Rows)
+
+	for _, row := range a.Rows {
+		if _, ok := bRows[RowKey(row)]; !ok {
+			diff.Removed = append(diff.Removed, row)
+		}
+	}
+
+	for _, row := range b.Rows {
+		key := RowKey(row)
+		previous, ok := aRows[key]
+		if !ok {
+			diff.Added = append(diff.Added, row)
+			continue
+		}
+		if !reflect.DeepEqual(previous, row) {
+			diff.Changed = append(diff.Changed, RowChange{Key: key, From: previous, To: row})
+		}
+	}
+
+	return diff, nil
+}
+
+//duplicateRowKey returns the first key (see RowKey) shared by two rows, and false if every row has its own key.
+func duplicateRowKey(rows []Row) (string, bool) {
+	seen := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		key := RowKey(row)
+		if seen[key] {
+			return key, true
+		}
+		seen[key] = true
+	}
+
+	return "", false
+}
+
+func diffMeta(a, b Meta) []MetaChange {
+	var changes []MetaChange
+
+	aValue := reflect.ValueOf(a)
+	bValue := reflect.ValueOf(b)
+	for i := 0; i < aValue.NumField(); i++ {
+		from := aValue.Field(i).Interface()
+		to := bValue.Field(i).Interface()
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+
+		fromJSON, _ := json.Marshal(from)
+		toJSON, _ := json.Marshal(to)
+		changes = append(changes, MetaChange{Field: metaFieldName(aValue.Type().Field(i)), From: fromJSON, To: toJSON})
+	}
+
+	return changes
+}
+
+//metaFieldName returns the JSON name of a Meta field.
+func metaFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+//Apply returns a copy of the table with the diff applied, the table itself is not modified.
+//ErrPatchDoesNotApply is returned if the table does not match the state the diff was made from, and ErrDuplicateRowKey
+//is returned if two rows in the table have the same key.
+func (t Table) Apply(d TableDiff) (Table, error) {
+	patched := Table{Meta: t.Meta}
+	metaValue := reflect.ValueOf(&patched.Meta).Elem()
+	for _, change := range d.Meta {
+		field, ok := metaField(metaValue, change.Field)
+		if !ok {
+			return Table{}, ErrPatchDoesNotApply
+		}
+
+		from := reflect.New(field.Type())
+		err := json.Unmarshal(change.From, from.Interface())
+		if err != nil || !reflect.DeepEqual(from.Elem().Interface(), field.Interface()) {
+			return Table{}, ErrPatchDoesNotApply
+		}
+
+		to := reflect.New(field.Type())
+		err = json.Unmarshal(change.To, to.Interface())
+		if err != nil {
+			return Table{}, ErrPatchDoesNotApply
+		}
+		field.Set(to.Elem())
+	}
+
+	if key, ok := duplicateRowKey(t.Rows); ok {
+		return Table{}, fmt.Errorf("%w, %s", ErrDuplicateRowKey, key)
+	}
+	rows := rowsByKey(t.Rows)
+
+	for _, row := range d.Removed {
+		key := RowKey(row)
+		if current, ok := rows[key]; !ok || !reflect.DeepEqual(current, row) {
+			return Table{}, ErrPatchDoesNotApply
+		}
+		delete(rows, key)
+	}
+
+	for _, change := range d.Changed {
+		if current, ok := rows[change.Key]; !ok || !reflect.DeepEqual(current, change.From) {
+			return Table{}, ErrPatchDoesNotApply
+		}
+		delete(rows, change.Key)
+		key := RowKey(change.To)
+		if _, ok := rows[key]; ok {
+			return Table{}, ErrPatchDoesNotApply
+		}
+		rows[key] = change.To
+	}
+
+	for _, row := range d.Added {
+		key := RowKey(row)
+		if _, ok := rows[key]; ok {
+			return Table{}, ErrPatchDoesNotApply
+		}
+		rows[key] = row
+	}
+
+	for _, row := range rows {
+		patched.Rows = append(patched.Rows, row)
+	}
+	sortRows(patched.Rows)
+
+	return patched, nil
+}
+
+//metaField returns the Meta field with the provided JSON name.
+func metaField(meta reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < meta.NumField(); i++ {
+		if metaFieldName(meta.Type().Field(i)) == name {
+			return meta.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+//sortRows sorts rows by their die roll, which is the start of the range for ranged rows.
+func sortRows(rows []Row) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].DieRoll < rows[j].DieRoll
+	})
+}
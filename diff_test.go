@@ -0,0 +1,226 @@
+package tables
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTable_ContentHash(t *testing.T) {
+	t.Run("validate that content hash is returned", func(t *testing.T) {
+		table := Table{Meta: Meta{Name: "test-table"}, Rows: []Row{{DieRoll: 1, Results: []string{"ONE", "TWO"}}}}
+
+		got := table.ContentHash()
+		want := "cce8c975443af87c99f46062de2f94fa1409b5eaf47b272087482a8fe0acc11e"
+		if got != want {
+			t.Errorf("want %s, got %s", want, got)
+		}
+	})
+
+	t.Run("validate that content hash changes when the table is edited", func(t *testing.T) {
+		table, err := Load(testCSV, "test", "Test", "d6")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		edited, err := Load(testCSV, "test", "Test", "d6")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if table.ContentHash() != edited.ContentHash() {
+			t.Errorf("expected equal tables to have equal content hashes")
+		}
+
+		edited.Rows[1].Results = []string{"2", "Changed", "Something new."}
+		if table.ContentHash() == edited.ContentHash() {
+			t.Errorf("expected edited table to have a different content hash")
+		}
+		if table.Hash() != edited.Hash() {
+			t.Errorf("expected edited table to have the same hash")
+		}
+	})
+}
+
+func Test_RowKey(t *testing.T) {
+	testCases := []struct {
+		name string
+		row  Row
+		want string
+	}{
+		{
+			name: "validate die roll is used for rows without a range",
+			row:  Row{DieRoll: 4},
+			want: "4",
+		},
+		{
+			name: "validate range is used for ranged rows",
+			row:  Row{DieRoll: 3, RollRange: "3-4"},
+			want: "3-4",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got := RowKey(test.row)
+
+			if got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_Diff(t *testing.T) {
+	t.Run("validate an empty diff is returned for equal tables", func(t *testing.T) {
+		a, _ := Load(testCSV, "test", "Test", "d6")
+		b, _ := Load(testCSV, "test", "Test", "d6")
+
+		got, _ := Diff(a, b)
+		if !got.Empty() {
+			t.Errorf("want empty diff, got %v", got)
+		}
+	})
+
+	t.Run("validate added, removed, and changed rows are returned", func(t *testing.T) {
+		a, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+		b, _ := Load([][]string{
+			{"D6", "Result"},
+			{"1-2", "You rolled a 1 or 2"},
+			{"3-4", "You rolled a 3 or 4, nice"},
+			{"5", "You rolled a 5"},
+			{"6", "You rolled a 6"},
+		}, "ranged", "Ranged", "d6")
+
+		got, _ := Diff(a, b)
+		wantRemoved := []Row{a.Rows[2]}
+		wantAdded := []Row{b.Rows[2], b.Rows[3]}
+		wantChanged := []RowChange{{Key: "3-4", From: a.Rows[1], To: b.Rows[1]}}
+		if !reflect.DeepEqual(wantRemoved, got.Removed) {
+			t.Errorf("want %v, got %v", wantRemoved, got.Removed)
+		}
+		if !reflect.DeepEqual(wantAdded, got.Added) {
+			t.Errorf("want %v, got %v", wantAdded, got.Added)
+		}
+		if !reflect.DeepEqual(wantChanged, got.Changed) {
+			t.Errorf("want %v, got %v", wantChanged, got.Changed)
+		}
+		if len(got.Meta) != 0 {
+			t.Errorf("want 0, got %d", len(got.Meta))
+		}
+	})
+
+	t.Run("validate changed meta fields are returned", func(t *testing.T) {
+		a, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+		b, _ := Load(rangedCSV, "ranged", "Ranged Again", "d6")
+		b.Meta.Headers = []string{"D6", "Outcome"}
+
+		got, _ := Diff(a, b)
+		want := []MetaChange{
+			{Field: "display_name", From: json.RawMessage(`"Ranged"`), To: json.RawMessage(`"Ranged Again"`)},
+			{Field: "headers", From: json.RawMessage(`["D6","Result"]`), To: json.RawMessage(`["D6","Outcome"]`)},
+		}
+		if !reflect.DeepEqual(want, got.Meta) {
+			t.Errorf("want %s, got %s", want, got.Meta)
+		}
+	})
+
+	t.Run("validate an error is returned for rows with the same key", func(t *testing.T) {
+		a, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+		b, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+		b.Rows = append(b.Rows, Row{DieRoll: 1, RollRange: "1-2", Results: []string{"1-2", "You rolled a 1 or 2 again"}})
+
+		_, err := Diff(a, b)
+		if !errors.Is(err, ErrDuplicateRowKey) {
+			t.Errorf("want %s, got %v", ErrDuplicateRowKey, err)
+		}
+		_, err = Diff(b, a)
+		if !errors.Is(err, ErrDuplicateRowKey) {
+			t.Errorf("want %s, got %v", ErrDuplicateRowKey, err)
+		}
+	})
+}
+
+func TestTable_Apply(t *testing.T) {
+	a, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+	b, _ := Load([][]string{
+		{"D6", "Outcome"},
+		{"1", "You rolled a 1"},
+		{"3-4", "You rolled a 3 or 4, nice"},
+		{"5-6", "You rolled a 5 or 6"},
+		{"2", "You rolled a 2"},
+	}, "ranged", "Ranged Again", "d6")
+	diff, _ := Diff(a, b)
+
+	t.Run("validate a diff can be applied to get the new table", func(t *testing.T) {
+		got, err := a.Apply(diff)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := b
+		sortRows(want.Rows)
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate a diff survives being encoded as json", func(t *testing.T) {
+		data, err := json.Marshal(diff)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		var patch TableDiff
+		err = json.Unmarshal(data, &patch)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		got, err := a.Apply(patch)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if changes, _ := Diff(b, got); !changes.Empty() {
+			t.Errorf("want %v, got %v", b, got)
+		}
+	})
+
+	t.Run("validate the original table is not modified", func(t *testing.T) {
+		want, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+		_, _ = a.Apply(diff)
+		if !reflect.DeepEqual(want, a) {
+			t.Errorf("want %v, got %v", want, a)
+		}
+	})
+
+	t.Run("validate an error is returned when the diff does not apply", func(t *testing.T) {
+		_, err := b.Apply(diff)
+		if err != ErrPatchDoesNotApply {
+			t.Errorf("want %s, got %v", ErrPatchDoesNotApply, err)
+		}
+	})
+
+	t.Run("validate an error is returned for a table with rows with the same key", func(t *testing.T) {
+		duplicated, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+		duplicated.Rows = append(duplicated.Rows, duplicated.Rows[0])
+
+		_, err := duplicated.Apply(TableDiff{})
+		if !errors.Is(err, ErrDuplicateRowKey) {
+			t.Errorf("want %s, got %v", ErrDuplicateRowKey, err)
+		}
+	})
+
+	t.Run("validate an error is returned when a changed row would replace another row", func(t *testing.T) {
+		_, err := a.Apply(TableDiff{Changed: []RowChange{{Key: "1-2", From: a.Rows[0], To: a.Rows[1]}}})
+		if err != ErrPatchDoesNotApply {
+			t.Errorf("want %s, got %v", ErrPatchDoesNotApply, err)
+		}
+	})
+
+	t.Run("validate an error is returned for an unknown meta field", func(t *testing.T) {
+		_, err := a.Apply(TableDiff{Meta: []MetaChange{{Field: "nope", From: json.RawMessage(`""`), To: json.RawMessage(`""`)}}})
+		if err != ErrPatchDoesNotApply {
+			t.Errorf("want %s, got %v", ErrPatchDoesNotApply, err)
+		}
+	})
+}
@@ -0,0 +1,431 @@
+package tables
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fantastical-world/dice"
+)
+
+//AddRow adds a row for the provided record. Rows in rollable tables are kept sorted by their roll,
+//all other rows are added to the end of the table. The record must include the roll column for rollable tables.
+func (t *Table) AddRow(record []string) error {
+	index := len(t.Rows)
+	if t.Meta.RollableTable && len(record) > 0 {
+		row, err := newRow(record, 0, true)
+		if err != nil {
+			return err
+		}
+		index = sort.Search(len(t.Rows), func(i int) bool { return t.Rows[i].DieRoll > row.DieRoll })
+	}
+
+	return t.InsertRow(index, record)
+}
+
+//InsertRow inserts a row for the provided record at index. For rollable tables the row's roll must fit
+//between the rolls of the rows around it, the rows of other tables are renumbered to match their new position.
+func (t *Table) InsertRow(index int, record []string) error {
+	if index < 0 || index > len(t.Rows) {
+		return ErrRowDoesNotExist
+	}
+
+	if len(record) != t.Meta.ColumnCount {
+		return ErrInvalidColumnCount
+	}
+
+	row, err := newRow(append([]string(nil), record...), index+1, t.Meta.RollableTable)
+	if err != nil {
+		return err
+	}
+
+	err = t.checkCells(record)
+	if err != nil {
+		return err
+	}
+
+	if t.Meta.RollableTable {
+		low, high := t.rollLimits()
+		err = t.checkRoll(row, -1, low, high)
+		if err != nil {
+			return err
+		}
+		if (index > 0 && t.Rows[index-1].DieRoll > row.DieRoll) || (index < len(t.Rows) && t.Rows[index].DieRoll < row.DieRoll) {
+			return ErrInvalidRowPosition
+		}
+	}
+
+	rows := make([]Row, 0, len(t.Rows)+1)
+	rows = append(rows, t.Rows[:index]...)
+	rows = append(rows, row)
+	rows = append(rows, t.Rows[index:]...)
+	t.Rows = rows
+	t.renumberPositions()
+
+	return nil
+}
+
+//RemoveRow removes the row at index.
+func (t *Table) RemoveRow(index int) error {
+	if index < 0 || index >= len(t.Rows) {
+		return ErrRowDoesNotExist
+	}
+
+	rows := make([]Row, 0, len(t.Rows)-1)
+	rows = append(rows, t.Rows[:index]...)
+	rows = append(rows, t.Rows[index+1:]...)
+	t.Rows = rows
+	t.renumberPositions()
+
+	return nil
+}
+
+//UpdateCell sets the value of a single cell. Updating the roll column of a rollable table will move the row
+//to keep rows sorted by roll.
+func (t *Table) UpdateCell(index, column int, value string) error {
+	if index < 0 || index >= len(t.Rows) {
+		return ErrRowDoesNotExist
+	}
+
+	if column < 0 || column >= len(t.Rows[index].Results) {
+		return ErrColumnDoesNotExist
+	}
+
+	results := append([]string(nil), t.Rows[index].Results...)
+	results[column] = value
+	row, err := newRow(results, index+1, t.Meta.RollableTable)
+	if err != nil {
+		return err
+	}
+
+	err = t.checkCells(results)
+	if err != nil {
+		return err
+	}
+	row.Locales = t.Rows[index].Locales
+	row.Meta = t.Rows[index].Meta
+
+	if t.Meta.RollableTable && column == 0 {
+		low, high := t.rollLimits()
+		err = t.checkRoll(row, index, low, high)
+		if err != nil {
+			return err
+		}
+	}
+
+	rows := append([]Row(nil), t.Rows...)
+	rows[index] = row
+	if t.Meta.RollableTable {
+		sortRows(rows)
+	}
+	t.Rows = rows
+
+	return nil
+}
+
+//AddColumn adds a column with the provided header to the end of the table, every row is given value for the new column.
+func (t *Table) AddColumn(header, value string) error {
+	headers, visibility := splitVisibility([]string{header})
+	headers, columnTypes := splitHeaders(headers)
+	header = headers[0]
+	if columnTypes != nil {
+		err := columnTypes[0].Check(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	rows := make([]Row, len(t.Rows))
+	for i, row := range t.Rows {
+		if len(row.Results) != t.Meta.ColumnCount {
+			return ErrInvalidColumnCount
+		}
+
+		results := make([]string, 0, len(row.Results)+1)
+		results = append(results, row.Results...)
+		results = append(results, value)
+		row.Results = results
+		row.HasRollExpression = row.HasRollExpression || RollableString(value)
+		row.Locales = appendLocales(row.Locales, value)
+		rows[i] = row
+	}
+
+	//translations are given the new column untranslated so they keep matching the table
+	if t.Meta.Locales != nil {
+		locales := make(map[string]MetaLocale, len(t.Meta.Locales))
+		for locale, meta := range t.Meta.Locales {
+			if meta.Headers != nil {
+				meta.Headers = append(append([]string(nil), meta.Headers...), header)
+			}
+			locales[locale] = meta
+		}
+		t.Meta.Locales = locales
+	}
+
+	if columnTypes != nil || t.Meta.ColumnTypes != nil {
+		types := make([]ColumnType, len(t.Meta.Headers), len(t.Meta.Headers)+1)
+		copy(types, t.Meta.ColumnTypes)
+		t.Meta.ColumnTypes = append(types, ColumnType(""))
+		if columnTypes != nil {
+			t.Meta.ColumnTypes[len(types)] = columnTypes[0]
+		}
+	}
+	if visibility != nil || t.Meta.Visibility != nil {
+		audiences := make([]Audience, len(t.Meta.Headers), len(t.Meta.Headers)+1)
+		copy(audiences, t.Meta.Visibility)
+		t.Meta.Visibility = append(audiences, "")
+		if visibility != nil {
+			t.Meta.Visibility[len(audiences)] = visibility[0]
+		}
+	}
+	t.Meta.Headers = append(append([]string(nil), t.Meta.Headers...), header)
+	t.Meta.ColumnCount = len(t.Meta.Headers)
+	t.Rows = rows
+
+	return nil
+}
+
+//RenameHeader sets the header of a column.
+func (t *Table) RenameHeader(column int, header string) error {
+	if column < 0 || column >= len(t.Meta.Headers) {
+		return ErrColumnDoesNotExist
+	}
+
+	headers := append([]string(nil), t.Meta.Headers...)
+	headers[column] = header
+	t.Meta.Headers = headers
+
+	return nil
+}
+
+//Renumber changes the roll expression of a rollable table, spreading its rows as evenly as possible across the
+//values of the new roll expression. The roll column of each row is rewritten to match, as is the roll column's
+//header if it named the previous roll expression (e.g. D6 becomes D8 when renumbering to d8).
+func (t *Table) Renumber(rollExpression string) error {
+	if !t.Meta.RollableTable {
+		return ErrTableNotRollable
+	}
+
+	low, high, err := rollBounds(rollExpression)
+	if err != nil {
+		return err
+	}
+
+	size := high - low + 1
+	if size < len(t.Rows) {
+		return ErrNotEnoughRollValues
+	}
+
+	rows := make([]Row, len(t.Rows))
+	for i, row := range t.Rows {
+		start := low + (i*size)/len(t.Rows)
+		end := low + ((i+1)*size)/len(t.Rows) - 1
+		rows[i] = withRoll(row, start, end)
+	}
+	t.Rows = rows
+	t.setRollExpression(rollExpression)
+
+	return nil
+}
+
+//Validate checks that the table's rows are consistent with its meta data. Every row must have a result for each
+//column, and the rows of a rollable table must have valid, non-overlapping rolls that can be rolled by its roll expression.
+//Cells must match the type of their column (see ColumnType).
+func (t Table) Validate() error {
+	if t.Meta.ColumnCount != len(t.Meta.Headers) {
+		return ErrInvalidColumnCount
+	}
+	if t.Meta.Visibility != nil && len(t.Meta.Visibility) != t.Meta.ColumnCount {
+		return ErrInvalidColumnCount
+	}
+	for _, meta := range t.Meta.Locales {
+		if meta.Headers != nil && len(meta.Headers) != t.Meta.ColumnCount {
+			return ErrInvalidColumnCount
+		}
+	}
+
+	low, high := t.rollLimits()
+	for i, row := range t.Rows {
+		if len(row.Results) != t.Meta.ColumnCount {
+			return ErrInvalidColumnCount
+		}
+		for _, results := range row.Locales {
+			if len(results) != t.Meta.ColumnCount {
+				return ErrInvalidColumnCount
+			}
+		}
+
+		if !t.Meta.RollableTable {
+			continue
+		}
+
+		if len(row.Results) == 0 {
+			return ErrInvalidRollColumn
+		}
+		derived, err := newRow(row.Results, 0, true)
+		if err != nil {
+			return err
+		}
+		if derived.DieRoll != row.DieRoll || derived.RollRange != row.RollRange {
+			return ErrInvalidRollColumn
+		}
+
+		err = t.checkRoll(row, i, low, high)
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.checkColumnTypes()
+}
+
+//checkRoll returns an error if row can not be rolled within low and high (see rollLimits), or if it overlaps any row
+//other than the row at skip.
+func (t Table) checkRoll(row Row, skip, low, high int) error {
+	start, end := rowBounds(row)
+	if start > end {
+		return ErrInvalidRollColumn
+	}
+	if start < low || end > high {
+		return ErrInvalidTableRollValue
+	}
+
+	for i, other := range t.Rows {
+		if i == skip {
+			continue
+		}
+		otherStart, otherEnd := rowBounds(other)
+		if start <= otherEnd && otherStart <= end {
+			return ErrOverlappingRoll
+		}
+	}
+
+	return nil
+}
+
+//setRollExpression sets the table's roll expression, and the roll column's header if it named the previous roll expression.
+func (t *Table) setRollExpression(rollExpression string) {
+	if len(t.Meta.Headers) > 0 && strings.EqualFold(t.Meta.Headers[0], t.Meta.RollExpression) {
+		header := rollExpression
+		if t.Meta.Headers[0] == strings.ToUpper(t.Meta.Headers[0]) {
+			header = strings.ToUpper(rollExpression)
+		}
+		t.RenameHeader(0, header)
+	}
+	t.Meta.RollExpression = rollExpression
+}
+
+//renumberPositions sets the die roll of each row in a table that isn't rollable to its position in the table.
+func (t *Table) renumberPositions() {
+	if t.Meta.RollableTable {
+		return
+	}
+
+	for i := range t.Rows {
+		t.Rows[i].DieRoll = i + 1
+	}
+}
+
+//appendLocales returns a copy of a row's translations with value added to the end of each.
+func appendLocales(locales map[string][]string, value string) map[string][]string {
+	if locales == nil {
+		return nil
+	}
+
+	appended := make(map[string][]string, len(locales))
+	for locale, results := range locales {
+		appended[locale] = append(append([]string(nil), results...), value)
+	}
+
+	return appended
+}
+
+//withRoll returns a copy of row that is rolled on values from start to end.
+func withRoll(row Row, start, end int) Row {
+	roll := strconv.Itoa(start)
+	row.DieRoll = start
+	row.RollRange = ""
+	if end > start {
+		roll = fmt.Sprintf("%d-%d", start, end)
+		row.RollRange = roll
+	}
+
+	results := append([]string(nil), row.Results...)
+	if len(results) > 0 {
+		results[0] = roll
+	}
+	row.Results = results
+
+	return row
+}
+
+//rollLimits returns the lowest and highest values of the table's roll expression. Tables with roll expressions we
+//can't read are left for RandomRow to report, so any value is allowed.
+func (t Table) rollLimits() (int, int) {
+	low, high, err := rollBounds(t.Meta.RollExpression)
+	if err != nil {
+		return math.MinInt, math.MaxInt
+	}
+
+	return low, high
+}
+
+//rollBounds returns the lowest and highest values that can be rolled with the provided roll expression, the same as
+//the lowest and highest values of RollDistribution. They are worked out from the dice, so expressions too large for
+//RollDistribution have bounds.
+func rollBounds(rollExpression string) (int, int, error) {
+	prefixes, expression := parseRollPrefixes(rollExpression)
+
+	match := dice.RollExpressionRE.FindStringSubmatch(expression)
+	if match == nil {
+		return 0, 0, ErrInvalidRollExpression
+	}
+
+	hasSecondExpression := match[5] != ""
+	if hasSecondExpression && (prefixes.wantsMax || prefixes.wantsMin) {
+		return 0, 0, ErrInvalidRollExpression
+	}
+
+	number, sides, ok := termDice(match[1], match[2])
+	if !ok {
+		return 0, 0, ErrInvalidRollExpression
+	}
+
+	modifier := termModifier(match[3], match[4])
+	kept := number
+	switch {
+	case prefixes.wantsMax || prefixes.wantsMin:
+		//dice.RollExpression returns the highest or lowest die before halving or doubling
+		return 1 + modifier, sides + modifier, nil
+	case prefixes.dropLowest && prefixes.dropHighest:
+		return 0, 0, ErrUnsupportedRollExpression
+	case prefixes.dropLowest || prefixes.dropHighest:
+		kept--
+	}
+	low, high := kept+modifier, kept*sides+modifier
+
+	if hasSecondExpression {
+		secondNumber, secondSides, ok := termDice(match[7], match[8])
+		if !ok {
+			return 0, 0, ErrInvalidRollExpression
+		}
+		secondModifier := termModifier(match[9], match[10])
+		secondLow, secondHigh := secondNumber+secondModifier, secondNumber*secondSides+secondModifier
+		if match[6] == "-" {
+			low, high = low-secondHigh, high-secondLow
+		} else {
+			low, high = low+secondLow, high+secondHigh
+		}
+	}
+
+	switch {
+	case prefixes.halfResult:
+		return low / 2, high / 2, nil
+	case prefixes.doubleResult:
+		return low * 2, high * 2, nil
+	}
+
+	return low, high, nil
+}
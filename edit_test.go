@@ -0,0 +1,477 @@
+package tables
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func rollColumn(table Table) []string {
+	var rolls []string
+	for _, row := range table.Rows {
+		rolls = append(rolls, row.Results[0])
+	}
+
+	return rolls
+}
+
+func TestTable_AddRow(t *testing.T) {
+	t.Run("validate rows are added in roll order", func(t *testing.T) {
+		table, _ := Load([][]string{{"D6", "Result"}, {"1-2", "Low"}, {"5-6", "High"}}, "test", "Test", "d6")
+
+		err := table.AddRow([]string{"3-4", "Middle {{1d4}}"})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := Row{DieRoll: 3, RollRange: "3-4", HasRollExpression: true, Results: []string{"3-4", "Middle {{1d4}}"}}
+		if !reflect.DeepEqual(want, table.Rows[1]) {
+			t.Errorf("want %v, got %v", want, table.Rows[1])
+		}
+	})
+
+	t.Run("validate rows are added to the end of tables that are not rollable", func(t *testing.T) {
+		table, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+
+		err := table.AddRow([]string{"SNK", "How sneaky the character is."})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := Row{DieRoll: 4, Results: []string{"SNK", "How sneaky the character is."}}
+		if !reflect.DeepEqual(want, table.Rows[3]) {
+			t.Errorf("want %v, got %v", want, table.Rows[3])
+		}
+	})
+
+	testCases := []struct {
+		name   string
+		record []string
+		want   error
+	}{
+		{
+			name:   "validate an error is returned for overlapping rolls",
+			record: []string{"2-3", "Overlaps"},
+			want:   ErrOverlappingRoll,
+		},
+		{
+			name:   "validate an error is returned for rolls the table can not roll",
+			record: []string{"7", "Too high"},
+			want:   ErrInvalidTableRollValue,
+		},
+		{
+			name:   "validate an error is returned for an invalid roll column",
+			record: []string{"three", "Not a roll"},
+			want:   ErrInvalidRollColumn,
+		},
+		{
+			name:   "validate an error is returned for the wrong number of columns",
+			record: []string{"3", "Result", "Extra"},
+			want:   ErrInvalidColumnCount,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			table, _ := Load([][]string{{"D6", "Result"}, {"1-2", "Low"}, {"5-6", "High"}}, "test", "Test", "d6")
+
+			got := table.AddRow(test.record)
+			if got != test.want {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+			if len(table.Rows) != 2 {
+				t.Errorf("want 2, got %d", len(table.Rows))
+			}
+		})
+	}
+}
+
+func TestTable_InsertRow(t *testing.T) {
+	t.Run("validate rows are renumbered when inserted in tables that are not rollable", func(t *testing.T) {
+		table, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+
+		err := table.InsertRow(1, []string{"SNK", "How sneaky the character is."})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		for i, row := range table.Rows {
+			if row.DieRoll != i+1 {
+				t.Errorf("want %d, got %d", i+1, row.DieRoll)
+			}
+		}
+		if table.Rows[1].Results[0] != "SNK" {
+			t.Errorf("want SNK, got %s", table.Rows[1].Results[0])
+		}
+	})
+
+	t.Run("validate an error is returned when a roll does not fit at the position", func(t *testing.T) {
+		table, _ := Load([][]string{{"D6", "Result"}, {"1-2", "Low"}, {"5-6", "High"}}, "test", "Test", "d6")
+
+		err := table.InsertRow(0, []string{"3", "Middle"})
+		if err != ErrInvalidRowPosition {
+			t.Errorf("want %s, got %v", ErrInvalidRowPosition, err)
+		}
+	})
+
+	t.Run("validate an error is returned for a position outside the table", func(t *testing.T) {
+		table, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+
+		err := table.InsertRow(9, []string{"SNK", "How sneaky the character is."})
+		if err != ErrRowDoesNotExist {
+			t.Errorf("want %s, got %v", ErrRowDoesNotExist, err)
+		}
+	})
+
+	t.Run("validate the records of copies of the table are not modified", func(t *testing.T) {
+		table, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+		original := table
+		want := table.Records()
+
+		_ = table.InsertRow(0, []string{"SNK", "How sneaky the character is."})
+		if !reflect.DeepEqual(want, original.Records()) {
+			t.Errorf("want %v, got %v", want, original.Records())
+		}
+	})
+}
+
+func TestTable_RemoveRow(t *testing.T) {
+	t.Run("validate row is removed and rows are renumbered", func(t *testing.T) {
+		table, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+
+		err := table.RemoveRow(0)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := []Row{
+			{DieRoll: 1, Results: nonRollableCSV[2]},
+			{DieRoll: 2, Results: nonRollableCSV[3]},
+		}
+		if !reflect.DeepEqual(want, table.Rows) {
+			t.Errorf("want %v, got %v", want, table.Rows)
+		}
+	})
+
+	t.Run("validate an error is returned for a row that does not exist", func(t *testing.T) {
+		table, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+
+		err := table.RemoveRow(3)
+		if err != ErrRowDoesNotExist {
+			t.Errorf("want %s, got %v", ErrRowDoesNotExist, err)
+		}
+	})
+}
+
+func TestTable_UpdateCell(t *testing.T) {
+	t.Run("validate the cell is updated and roll expressions are detected", func(t *testing.T) {
+		table, _ := Load(testCSV, "test", "Test", "d6")
+
+		err := table.UpdateCell(1, 1, "{{1d4}} kobolds")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := Row{DieRoll: 2, HasRollExpression: true, Results: []string{"2", "{{1d4}} kobolds", "Nothing to see here."}}
+		if !reflect.DeepEqual(want, table.Rows[1]) {
+			t.Errorf("want %v, got %v", want, table.Rows[1])
+		}
+	})
+
+	t.Run("validate rows are sorted when the roll column is updated", func(t *testing.T) {
+		table, _ := Load([][]string{{"D6", "Result"}, {"1", "Low"}, {"5-6", "High"}}, "test", "Test", "d6")
+
+		err := table.UpdateCell(1, 0, "2-6")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		err = table.UpdateCell(0, 0, "1")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := []string{"1", "2-6"}
+		if !reflect.DeepEqual(want, rollColumn(table)) {
+			t.Errorf("want %v, got %v", want, rollColumn(table))
+		}
+		if table.Rows[1].RollRange != "2-6" || table.Rows[1].DieRoll != 2 {
+			t.Errorf("want 2-6, got %v", table.Rows[1])
+		}
+	})
+
+	t.Run("validate an error is returned when the roll column overlaps another row", func(t *testing.T) {
+		table, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+
+		err := table.UpdateCell(0, 0, "1-3")
+		if err != ErrOverlappingRoll {
+			t.Errorf("want %s, got %v", ErrOverlappingRoll, err)
+		}
+	})
+
+	t.Run("validate an error is returned for a column that does not exist", func(t *testing.T) {
+		table, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+
+		err := table.UpdateCell(0, 2, "nope")
+		if err != ErrColumnDoesNotExist {
+			t.Errorf("want %s, got %v", ErrColumnDoesNotExist, err)
+		}
+	})
+}
+
+func TestTable_AddColumn(t *testing.T) {
+	t.Run("validate column is added to the header and every row", func(t *testing.T) {
+		table, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+
+		err := table.AddColumn("Bonus", "{{1d4}}")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := [][]string{
+			{"D6", "Result", "Bonus"},
+			{"1-2", "You rolled a 1 or 2", "{{1d4}}"},
+			{"3-4", "You rolled a 3 or 4", "{{1d4}}"},
+			{"5-6", "You rolled a 5 or 6", "{{1d4}}"},
+		}
+		if !reflect.DeepEqual(want, table.Records()) {
+			t.Errorf("want %v, got %v", want, table.Records())
+		}
+		if table.Meta.ColumnCount != 3 {
+			t.Errorf("want 3, got %d", table.Meta.ColumnCount)
+		}
+		if !table.Rows[0].HasRollExpression {
+			t.Errorf("expected row to have a roll expression")
+		}
+	})
+}
+
+func TestTable_RenameHeader(t *testing.T) {
+	t.Run("validate header is renamed", func(t *testing.T) {
+		table, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+
+		err := table.RenameHeader(1, "Outcome")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := []string{"D6", "Outcome"}
+		if !reflect.DeepEqual(want, table.Header()) {
+			t.Errorf("want %v, got %v", want, table.Header())
+		}
+	})
+
+	t.Run("validate an error is returned for a column that does not exist", func(t *testing.T) {
+		table, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+
+		err := table.RenameHeader(-1, "Outcome")
+		if err != ErrColumnDoesNotExist {
+			t.Errorf("want %s, got %v", ErrColumnDoesNotExist, err)
+		}
+	})
+}
+
+func TestTable_Renumber(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rollExpression string
+		want           []string
+		wantHeader     string
+	}{
+		{
+			name:           "validate rows are spread across a larger die",
+			rollExpression: "d12",
+			want:           []string{"1-4", "5-8", "9-12"},
+			wantHeader:     "D12",
+		},
+		{
+			name:           "validate rows are spread as evenly as possible",
+			rollExpression: "d8",
+			want:           []string{"1-2", "3-5", "6-8"},
+			wantHeader:     "D8",
+		},
+		{
+			name:           "validate rows are spread across multiple dice",
+			rollExpression: "2d6",
+			want:           []string{"2-4", "5-8", "9-12"},
+			wantHeader:     "2D6",
+		},
+		{
+			name:           "validate rows can be given a single roll",
+			rollExpression: "d3",
+			want:           []string{"1", "2", "3"},
+			wantHeader:     "D3",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			table, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+
+			err := table.Renumber(test.rollExpression)
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+
+			if !reflect.DeepEqual(test.want, rollColumn(table)) {
+				t.Errorf("want %v, got %v", test.want, rollColumn(table))
+			}
+			if table.Meta.Headers[0] != test.wantHeader {
+				t.Errorf("want %s, got %s", test.wantHeader, table.Meta.Headers[0])
+			}
+			if table.Meta.RollExpression != test.rollExpression {
+				t.Errorf("want %s, got %s", test.rollExpression, table.Meta.RollExpression)
+			}
+			if err := table.Validate(); err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+		})
+	}
+
+	t.Run("validate an error is returned when the die is too small", func(t *testing.T) {
+		table, _ := Load(testCSV, "test", "Test", "d6")
+
+		err := table.Renumber("d4")
+		if err != ErrNotEnoughRollValues {
+			t.Errorf("want %s, got %v", ErrNotEnoughRollValues, err)
+		}
+	})
+
+	t.Run("validate an error is returned for an invalid roll expression", func(t *testing.T) {
+		table, _ := Load(testCSV, "test", "Test", "d6")
+
+		err := table.Renumber("twenty")
+		if err != ErrInvalidRollExpression {
+			t.Errorf("want %s, got %v", ErrInvalidRollExpression, err)
+		}
+	})
+
+	t.Run("validate an error is returned for a table that is not rollable", func(t *testing.T) {
+		table, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+
+		err := table.Renumber("d6")
+		if err != ErrTableNotRollable {
+			t.Errorf("want %s, got %v", ErrTableNotRollable, err)
+		}
+	})
+}
+
+func TestTable_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		table func() Table
+		want  error
+	}{
+		{
+			name:  "validate a loaded table is valid",
+			table: func() Table { table, _ := Load(testCSV, "test", "Test", "d6"); return table },
+			want:  nil,
+		},
+		{
+			name:  "validate a table that is not rollable is valid",
+			table: func() Table { table, _ := Load(nonRollableCSV, "abilities", "Abilities", ""); return table },
+			want:  nil,
+		},
+		{
+			name: "validate overlapping rows are invalid",
+			table: func() Table {
+				table, _ := Load([][]string{{"D6", "Result"}, {"1-4", "Low"}, {"3-6", "High"}}, "test", "Test", "d6")
+				return table
+			},
+			want: ErrOverlappingRoll,
+		},
+		{
+			name: "validate rows that can not be rolled are invalid",
+			table: func() Table {
+				table, _ := Load(rangedCSV, "test", "Test", "17d6")
+				return table
+			},
+			want: ErrInvalidTableRollValue,
+		},
+		{
+			name: "validate rows with missing columns are invalid",
+			table: func() Table {
+				table, _ := Load([][]string{{"D6", "Result"}, {"1-3", "Low"}, {"4-6"}}, "test", "Test", "d6")
+				return table
+			},
+			want: ErrInvalidColumnCount,
+		},
+		{
+			name: "validate rows that do not match their roll column are invalid",
+			table: func() Table {
+				table, _ := Load(rangedCSV, "test", "Test", "d6")
+				table.Rows[0].DieRoll = 2
+				return table
+			},
+			want: ErrInvalidRollColumn,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.table().Validate()
+
+			if got != test.want {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_rollBounds(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		wantLow    int
+		wantHigh   int
+		wantErr    error
+	}{
+		{name: "validate bounds of a single die", expression: "d20", wantLow: 1, wantHigh: 20},
+		{name: "validate bounds of multiple dice", expression: "3d6", wantLow: 3, wantHigh: 18},
+		{name: "validate bounds with a modifier", expression: "2d4-1", wantLow: 1, wantHigh: 7},
+		{name: "validate bounds of an expression pair", expression: "1d6+1d4", wantLow: 2, wantHigh: 10},
+		{name: "validate bounds of a subtracted expression pair", expression: "1d6-1d4", wantLow: -3, wantHigh: 5},
+		{name: "validate bounds of the highest die ignore halving", expression: "max:half:3d6", wantLow: 1, wantHigh: 6},
+		{name: "validate bounds of the lowest die ignore doubling", expression: "min:dub:2d4+1", wantLow: 2, wantHigh: 5},
+		{name: "validate bounds dropping a die", expression: "dropL:4d6", wantLow: 3, wantHigh: 18},
+		{name: "validate bounds of a halved expression pair", expression: "half:1d6-1d4+1", wantLow: -2, wantHigh: 2},
+		{name: "validate bounds of expressions too large for a distribution", expression: "1000d1000", wantLow: 1000, wantHigh: 1000000},
+		{name: "validate an error is returned for dropping both dice", expression: "dropL:dropH:3d6", wantErr: ErrUnsupportedRollExpression},
+		{name: "validate an error is returned for no dice", expression: "0d6", wantErr: ErrInvalidRollExpression},
+		{name: "validate an error is returned for an invalid expression", expression: "d", wantErr: ErrInvalidRollExpression},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			low, high, err := rollBounds(test.expression)
+
+			if err != test.wantErr {
+				t.Errorf("want %v, got %v", test.wantErr, err)
+			}
+			if low != test.wantLow || high != test.wantHigh {
+				t.Errorf("want %d-%d, got %d-%d", test.wantLow, test.wantHigh, low, high)
+			}
+		})
+	}
+
+	for _, expression := range []string{"3d6+2", "dropH:4d6", "dub:2d4-1d6", "half:3d6-2", "max:4d8-1", "min:dropL:3d6", "half:d6-d10"} {
+		t.Run("validate bounds match the distribution of "+expression, func(t *testing.T) {
+			distribution, err := RollDistribution(expression)
+			if err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+			wantLow, wantHigh := math.MaxInt, math.MinInt
+			for value := range distribution {
+				wantLow, wantHigh = min(wantLow, value), max(wantHigh, value)
+			}
+
+			low, high, err := rollBounds(expression)
+
+			if err != nil {
+				t.Errorf("want no error, got %v", err)
+			}
+			if low != wantLow || high != wantHigh {
+				t.Errorf("want %d-%d, got %d-%d", wantLow, wantHigh, low, high)
+			}
+		})
+	}
+}
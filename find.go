@@ -0,0 +1,101 @@
+package tables
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const ErrInvalidFindQuery = TableError("not a valid find query")
+
+//FindResult is a row that matched a query, with the probability of rolling it.
+type FindResult struct {
+	Row
+	//Index is the index of the row in the table's rows.
+	Index       int
+	Probability float64
+	//ProbabilityKnown is false if probabilities are not supported for the table's roll expression, Probability is 0.
+	ProbabilityKnown bool
+}
+
+//Find returns the rows with a cell matching query, in the order of the table's rows. A query matches cells that
+//contain it, ignoring case (e.g. dragon), or a regular expression between slashes (e.g. /^red (dragon|wyrm)$/).
+//Prefixing a query with a column's header and = only matches cells in that column (e.g. Monster=dragon, Trap DC=/1[5-9]/).
+//The roll column of rollable tables is only matched by its header, and a roll matches the row it rolls (e.g. D6=5
+//matches 4-5). Cells are matched as they are written, before any roll expressions in them are rolled.
+func (t Table) Find(query string) ([]FindResult, error) {
+	columns, match, err := t.parseFindQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	//rows are still found when probabilities are not supported for the roll expression
+	probabilities, err := t.Probabilities()
+	if err != nil && !errors.Is(err, ErrUnsupportedRollExpression) {
+		return nil, err
+	}
+
+	var found []FindResult
+	for i, row := range t.Rows {
+		for _, column := range columns {
+			if column < len(row.Results) && match(row.Results[column]) {
+				result := FindResult{Row: row, Index: i}
+				if probabilities != nil {
+					result.Probability = probabilities[i]
+					result.ProbabilityKnown = true
+				}
+				found = append(found, result)
+				break
+			}
+		}
+	}
+
+	return found, nil
+}
+
+//parseFindQuery returns the columns a query matches and the function matching their cells.
+func (t Table) parseFindQuery(query string) ([]int, func(cell string) bool, error) {
+	var columns []int
+	if header, value, ok := strings.Cut(query, "="); ok {
+		if column, err := t.ColumnIndex(strings.TrimSpace(header)); err == nil {
+			columns = []int{column}
+			query = value
+		}
+	}
+	if columns == nil {
+		first := 0
+		if t.Meta.RollableTable {
+			first = 1
+		}
+		for i := first; i < t.Meta.ColumnCount; i++ {
+			columns = append(columns, i)
+		}
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil, ErrInvalidFindQuery
+	}
+
+	//a roll matches the row GetRow returns for it, including rows with a range of rolls
+	if roll, err := strconv.Atoi(query); err == nil && t.Meta.RollableTable && len(columns) == 1 && columns[0] == 0 {
+		return columns, func(cell string) bool {
+			return cell == query || RollInRange(roll, cell)
+		}, nil
+	}
+
+	if len(query) > 1 && strings.HasPrefix(query, "/") && strings.HasSuffix(query, "/") {
+		expression, err := regexp.Compile(query[1 : len(query)-1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w, %s", ErrInvalidFindQuery, err)
+		}
+		return columns, expression.MatchString, nil
+	}
+
+	lower := strings.ToLower(query)
+	return columns, func(cell string) bool {
+		return strings.Contains(strings.ToLower(cell), lower)
+	}, nil
+}
@@ -0,0 +1,124 @@
+package tables
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTable_Find(t *testing.T) {
+	table, err := Load([][]string{
+		{"2D6", "Monster", "Lair"},
+		{"2-4", "Red Dragon", "Volcano"},
+		{"5-9", "Goblins", "Cave with a dragon skull"},
+		{"10-11", "Wyrm", "Mountain"},
+		{"12", "Dragon Turtle", "{{1d4}} islands"},
+	}, "monsters", "Monsters", "2d6")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{"validate substrings match any column", "dragon", []int{0, 1, 3}},
+		{"validate columns can be matched", "Monster=dragon", []int{0, 3}},
+		{"validate headers ignore case", "lair = CAVE", []int{1}},
+		{"validate regular expressions match", "/^(Wyrm|Goblins)$/", []int{1, 2}},
+		{"validate regular expressions match columns", "Monster=/Dragon$/", []int{0}},
+		{"validate rolls match their row", "2D6=7", []int{1}},
+		{"validate the roll column is not matched without its header", "12", nil},
+		{"validate roll expressions are matched as written", "1d4", []int{3}},
+		{"validate unknown headers are part of the query", "Size=large", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			found, err := table.Find(test.query)
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+
+			var got []int
+			for _, result := range found {
+				got = append(got, result.Index)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	t.Run("validate results have their rolls and probability", func(t *testing.T) {
+		got, err := table.Find("Monster=red dragon")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		want := []FindResult{{Row: table.Rows[0], Index: 0, Probability: 6.0 / 36}}
+		if len(got) != 1 || !reflect.DeepEqual(want[0].Row, got[0].Row) || got[0].RollRange != "2-4" {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+		if diff := got[0].Probability - want[0].Probability; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("want %f, got %f", want[0].Probability, got[0].Probability)
+		}
+	})
+
+	t.Run("validate rows of tables that are not rollable are equally likely", func(t *testing.T) {
+		abilities, _ := Load([][]string{{"Ability", "Description"}, {"FUN", "Funness"}, {"BTR", "Bitterness"}}, "abilities", "Abilities", "")
+		got, err := abilities.Find("Ability=fun")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if len(got) != 1 || got[0].Index != 0 || got[0].Probability != 0.5 {
+			t.Errorf("want FUN with a probability of 0.5, got %v", got)
+		}
+	})
+
+	t.Run("validate rows are found when probabilities are not supported", func(t *testing.T) {
+		stats, err := Load([][]string{{"Roll", "Stat"}, {"2-9", "Weak"}, {"10-18", "Strong"}}, "stats", "Stats", "dropL:dropH:4d6")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		got, err := stats.Find("strong")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if len(got) != 1 || got[0].Index != 1 || got[0].ProbabilityKnown {
+			t.Errorf("want Strong with an unknown probability, got %v", got)
+		}
+	})
+
+	t.Run("validate rows that can't be rolled have a known probability of 0", func(t *testing.T) {
+		sums := Table{
+			Meta: Meta{Name: "sums", Headers: []string{"2D6", "Sum"}, ColumnCount: 2, RollableTable: true, RollExpression: "2d6"},
+			Rows: []Row{{DieRoll: 1, Results: []string{"1", "Snake eye"}}, {DieRoll: 2, Results: []string{"2", "Snake eyes"}}},
+		}
+		got, err := sums.Find("Sum=snake eye")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if len(got) != 2 || !got[0].ProbabilityKnown || got[0].Probability != 0 || got[1].Probability != 1.0/36 {
+			t.Errorf("want a known probability of 0 and 1/36, got %v", got)
+		}
+	})
+
+	t.Run("validate an error is returned for an invalid roll expression", func(t *testing.T) {
+		broken := Table{Meta: Meta{Name: "broken", Headers: []string{"Roll", "Result"}, ColumnCount: 2, RollableTable: true, RollExpression: "d"}, Rows: []Row{{DieRoll: 1, Results: []string{"1", "Gold"}}}}
+		_, err := broken.Find("gold")
+		if err != ErrInvalidRollExpression {
+			t.Errorf("want %v, got %v", ErrInvalidRollExpression, err)
+		}
+	})
+
+	for _, query := range []string{"", "Monster=", "/(/"} {
+		t.Run("validate an error is returned for the query "+query, func(t *testing.T) {
+			_, err := table.Find(query)
+			if !errors.Is(err, ErrInvalidFindQuery) {
+				t.Errorf("want %v, got %v", ErrInvalidFindQuery, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package tables
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fantastical-world/dice"
+	"gopkg.in/yaml.v3"
+)
+
+const ErrUnsupportedFormat = TableError("table format is not supported")
+
+//Format is a file format tables can be read from and written to.
+type Format string
+
+const (
+	FormatCSV      Format = "csv"
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "markdown"
+	FormatYAML     Format = "yaml"
+)
+
+var (
+	markdownSeparatorRE = regexp.MustCompile(`^:?-+:?$`)
+)
+
+//FormatFromPath returns the format of a file based on its extension.
+func FormatFromPath(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, true
+	case ".json":
+		return FormatJSON, true
+	case ".md", ".markdown":
+		return FormatMarkdown, true
+	case ".yaml", ".yml":
+		return FormatYAML, true
+	}
+
+	return "", false
+}
+
+//Decode reads a table in the provided format. JSON and YAML hold a complete table (see Pack), and name is only used if
+//the table doesn't have one. CSV and Markdown hold records for Load, the table is given the provided name, and it is
+//rollable if the first header is a roll expression (e.g. D6 or 2d4). Markdown may start with a # heading, used as the
+//display name, and a paragraph of flavor text before the table.
+func Decode(r io.Reader, format Format, name string) (Table, error) {
+	switch format {
+	case FormatJSON, FormatYAML:
+		table := Table{}
+		var err error
+		if format == FormatJSON {
+			err = json.NewDecoder(r).Decode(&table)
+		} else {
+			err = yaml.NewDecoder(r).Decode(&table)
+		}
+		if err != nil {
+			return Table{}, err
+		}
+		if table.Meta.Name == "" {
+			table.Meta.Name = name
+		}
+		return table, nil
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		if err != nil {
+			return Table{}, err
+		}
+		return loadRecords(records, name, name, "")
+	case FormatMarkdown:
+		return decodeMarkdown(r, name)
+	}
+
+	return Table{}, ErrUnsupportedFormat
+}
+
+//Encode writes the table in the provided format. CSV only includes the table's records, and Markdown its display name,
+//flavor text, and records. The headers of typed columns are annotated with their type, and row meta data is written
+//as meta data columns (see MetaColumnPrefix), so they can be decoded again.
+func Encode(w io.Writer, format Format, t Table) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(t)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		err := encoder.Encode(t)
+		if err != nil {
+			return err
+		}
+		return encoder.Close()
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		err := writer.WriteAll(t.annotatedRecords())
+		if err != nil {
+			return err
+		}
+		return writer.Error()
+	case FormatMarkdown:
+		return encodeMarkdown(w, t)
+	}
+
+	return ErrUnsupportedFormat
+}
+
+//LoadFile reads the table in the file at path, the format is based on the file's extension and the table is named
+//after the file (e.g. tables/loot.csv is named loot). Row meta data is added from a sidecar file if there is one
+//(e.g. tables/loot.meta.yaml or tables/loot.meta.json), mapping row keys to their meta data (see AddRowMeta).
+func LoadFile(path string) (Table, error) {
+	format, ok := FormatFromPath(path)
+	if !ok {
+		return Table{}, ErrUnsupportedFormat
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return Table{}, err
+	}
+	defer f.Close()
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	table, err := Decode(f, format, name)
+	if err != nil {
+		return Table{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	meta, err := loadSidecar(path)
+	if err == nil {
+		err = table.AddRowMeta(meta)
+	}
+	if err != nil {
+		return Table{}, fmt.Errorf("%s: row meta data: %w", path, err)
+	}
+
+	return table, nil
+}
+
+//LoadDir reads every table in dir and its subdirectories, files that are not in a supported format and sidecar files
+//are skipped.
+func LoadDir(dir string) ([]Table, error) {
+	var tables []Table
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(entry.Name(), ".") && path != dir {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if _, ok := FormatFromPath(path); entry.IsDir() || !ok || sidecarFile(path) {
+			return nil
+		}
+
+		table, err := LoadFile(path)
+		if err != nil {
+			return err
+		}
+		tables = append(tables, table)
+
+		return nil
+	})
+
+	return tables, err
+}
+
+//RollExpressionFromHeader returns the roll expression named by a roll column header (e.g. D6 returns d6),
+//or an empty string if the header is not a roll expression.
+func RollExpressionFromHeader(header string) string {
+	expression := strings.ToLower(strings.TrimSpace(header))
+	if !dice.ValidRollExpression(expression) {
+		return ""
+	}
+
+	return expression
+}
+
+//loadRecords loads records the same as Load, using the roll expression named by the first header if none is provided.
+func loadRecords(records [][]string, name, displayName, rollExpression string) (Table, error) {
+	if len(records) == 0 {
+		return Table{}, ErrTableInvalid
+	}
+
+	if rollExpression == "" {
+		rollExpression = RollExpressionFromHeader(records[0][0])
+	}
+
+	return Load(records, name, displayName, rollExpression)
+}
+
+func decodeMarkdown(r io.Reader, name string) (Table, error) {
+	displayName := name
+	var flavorText []string
+	var records [][]string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "|") {
+			cells := splitMarkdownRow(line)
+			if len(records) == 1 && markdownSeparator(cells) {
+				continue
+			}
+			records = append(records, cells)
+			continue
+		}
+
+		//anything after the table is not part of it
+		if len(records) > 0 {
+			break
+		}
+
+		switch {
+		case strings.HasPrefix(line, "# "):
+			displayName = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		case line != "":
+			flavorText = append(flavorText, line)
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return Table{}, err
+	}
+
+	table, err := loadRecords(records, name, displayName, "")
+	if err != nil {
+		return Table{}, err
+	}
+	table.Meta.FlavorText = strings.Join(flavorText, " ")
+
+	return table, nil
+}
+
+func encodeMarkdown(w io.Writer, t Table) error {
+	var b strings.Builder
+	if t.Meta.DisplayName != "" {
+		fmt.Fprintf(&b, "# %s\n\n", t.Meta.DisplayName)
+	}
+	if t.Meta.FlavorText != "" {
+		fmt.Fprintf(&b, "%s\n\n", t.Meta.FlavorText)
+	}
+
+	for i, record := range t.annotatedRecords() {
+		writeMarkdownRow(&b, record)
+		if i == 0 {
+			separator := make([]string, len(record))
+			for j := range separator {
+				separator[j] = "---"
+			}
+			writeMarkdownRow(&b, separator)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(cell, "\n", "<br>")
+		fmt.Fprintf(b, " %s |", cell)
+	}
+	b.WriteString("\n")
+}
+
+//splitMarkdownRow returns the cells of a Markdown table row, pipes escaped with a backslash are part of a cell.
+func splitMarkdownRow(line string) []string {
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	cells = append(cells, strings.TrimSpace(cell.String()))
+
+	for i := range cells {
+		cells[i] = strings.ReplaceAll(cells[i], "<br>", "\n")
+	}
+
+	return cells
+}
+
+func markdownSeparator(cells []string) bool {
+	for _, cell := range cells {
+		if !markdownSeparatorRE.MatchString(cell) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,233 @@
+package tables
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const markdownTable = `# Forest Encounters
+
+Something stirs in the trees.
+
+| D6 | Result | Description |
+| --- | :---: | --- |
+| 1-2 | Wolves | A pack of {{1d4+1}} wolves. |
+| 3-4 | Bandits | They want your gold \| your life. |
+| 5-6 | Nothing | All is quiet.<br>For now. |
+
+This is not part of the table.
+`
+
+func Test_FormatFromPath(t *testing.T) {
+	testCases := []struct {
+		path   string
+		want   Format
+		wantOK bool
+	}{
+		{path: "loot.csv", want: FormatCSV, wantOK: true},
+		{path: "tables/loot.JSON", want: FormatJSON, wantOK: true},
+		{path: "loot.md", want: FormatMarkdown, wantOK: true},
+		{path: "loot.markdown", want: FormatMarkdown, wantOK: true},
+		{path: "loot.yml", want: FormatYAML, wantOK: true},
+		{path: "loot.yaml", want: FormatYAML, wantOK: true},
+		{path: "loot.txt", want: "", wantOK: false},
+	}
+
+	for _, test := range testCases {
+		t.Run("validate format of "+test.path, func(t *testing.T) {
+			got, ok := FormatFromPath(test.path)
+
+			if got != test.want || ok != test.wantOK {
+				t.Errorf("want %s %t, got %s %t", test.want, test.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func Test_Decode(t *testing.T) {
+	t.Run("validate a csv table is loaded and rollable from its header", func(t *testing.T) {
+		got, err := Decode(strings.NewReader("D6,Result\n1-3,Low\n4-6,High {{1d4}}\n"), FormatCSV, "test")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want, _ := Load([][]string{{"D6", "Result"}, {"1-3", "Low"}, {"4-6", "High {{1d4}}"}}, "test", "test", "d6")
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate a csv table without a roll column is not rollable", func(t *testing.T) {
+		got, err := Decode(strings.NewReader("Ability,Description\nFUN,Funness\n"), FormatCSV, "abilities")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if got.Meta.RollableTable {
+			t.Errorf("expected table to not be rollable")
+		}
+	})
+
+	t.Run("validate a markdown table is loaded with its display name and flavor text", func(t *testing.T) {
+		got, err := Decode(strings.NewReader(markdownTable), FormatMarkdown, "forest")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want, _ := Load([][]string{
+			{"D6", "Result", "Description"},
+			{"1-2", "Wolves", "A pack of {{1d4+1}} wolves."},
+			{"3-4", "Bandits", "They want your gold | your life."},
+			{"5-6", "Nothing", "All is quiet.\nFor now."},
+		}, "forest", "Forest Encounters", "d6")
+		want.Meta.FlavorText = "Something stirs in the trees."
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate an error is returned for markdown without a table", func(t *testing.T) {
+		_, err := Decode(strings.NewReader("# Just a heading\n"), FormatMarkdown, "nope")
+		if err != ErrTableInvalid {
+			t.Errorf("want %s, got %v", ErrTableInvalid, err)
+		}
+	})
+
+	t.Run("validate an error is returned for an unsupported format", func(t *testing.T) {
+		_, err := Decode(strings.NewReader(""), Format("xml"), "nope")
+		if err != ErrUnsupportedFormat {
+			t.Errorf("want %s, got %v", ErrUnsupportedFormat, err)
+		}
+	})
+}
+
+func Test_Encode(t *testing.T) {
+	table, _ := Decode(strings.NewReader(markdownTable), FormatMarkdown, "forest")
+
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		t.Run("validate a table survives being encoded as "+string(format), func(t *testing.T) {
+			var b bytes.Buffer
+			err := Encode(&b, format, table)
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+
+			got, err := Decode(&b, format, "")
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+			if !reflect.DeepEqual(table, got) {
+				t.Errorf("want %v, got %v", table, got)
+			}
+		})
+	}
+
+	for _, format := range []Format{FormatCSV, FormatMarkdown} {
+		t.Run("validate table records survive being encoded as "+string(format), func(t *testing.T) {
+			var b bytes.Buffer
+			err := Encode(&b, format, table)
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+
+			got, err := Decode(&b, format, "forest")
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+			if !reflect.DeepEqual(table.Records(), got.Records()) {
+				t.Errorf("want %v, got %v", table.Records(), got.Records())
+			}
+			if !reflect.DeepEqual(table.Rows, got.Rows) {
+				t.Errorf("want %v, got %v", table.Rows, got.Rows)
+			}
+		})
+	}
+
+	t.Run("validate markdown includes the display name and flavor text", func(t *testing.T) {
+		var b bytes.Buffer
+		err := Encode(&b, FormatMarkdown, table)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := "# Forest Encounters\n\nSomething stirs in the trees.\n\n| D6 | Result | Description |\n| --- | --- | --- |\n" +
+			"| 1-2 | Wolves | A pack of {{1d4+1}} wolves. |\n| 3-4 | Bandits | They want your gold \\| your life. |\n" +
+			"| 5-6 | Nothing | All is quiet.<br>For now. |\n"
+		if b.String() != want {
+			t.Errorf("want %s, got %s", want, b.String())
+		}
+	})
+}
+
+func Test_LoadDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"encounters.csv":      "D6,Result\n1-3,Wolves\n4-6,Bandits\n",
+		"forest/trees.md":     "| D4 | Tree |\n| --- | --- |\n| 1-2 | Oak |\n| 3-4 | Pine |\n",
+		"notes.txt":           "not a table",
+		".hidden/secrets.csv": "D2,Secret\n1,One\n2,Two\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		_ = os.MkdirAll(filepath.Dir(path), 0o755)
+		err := os.WriteFile(path, []byte(content), 0o644)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+	}
+
+	t.Run("validate every supported table file is loaded", func(t *testing.T) {
+		got, err := LoadDir(dir)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		var names []string
+		for _, table := range got {
+			names = append(names, table.Meta.Name)
+		}
+		want := []string{"encounters", "trees"}
+		if !reflect.DeepEqual(want, names) {
+			t.Errorf("want %v, got %v", want, names)
+		}
+	})
+
+	t.Run("validate an error is returned for an invalid table file", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(dir, "broken.csv"), []byte("D6,Result\nA,Bad\n"), 0o644)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		_, err = LoadDir(dir)
+		if err == nil {
+			t.Errorf("expected an error, error was nil")
+		}
+	})
+}
+
+func Test_RollExpressionFromHeader(t *testing.T) {
+	testCases := []struct {
+		header string
+		want   string
+	}{
+		{header: "D6", want: "d6"},
+		{header: " 2d4 ", want: "2d4"},
+		{header: "d100", want: "d100"},
+		{header: "Roll", want: ""},
+		{header: "", want: ""},
+	}
+
+	for _, test := range testCases {
+		t.Run("validate roll expression from "+test.header, func(t *testing.T) {
+			got := RollExpressionFromHeader(test.header)
+
+			if got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
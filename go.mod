@@ -4,4 +4,18 @@ go 1.21
 
 toolchain go1.21.3
 
-require github.com/fantastical-world/dice v0.22.0
+require (
+	github.com/fantastical-world/dice v0.22.0
+	github.com/mattn/go-sqlite3 v1.14.22
+	golang.org/x/term v0.27.0
+	google.golang.org/grpc v1.66.2
+	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117 // indirect
+)
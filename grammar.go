@@ -0,0 +1,311 @@
+package tables
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const ErrInvalidGrammar = TableError("not a valid grammar")
+const ErrGrammarDepth = TableError("grammar expansion is too deep, a symbol may expand to itself")
+const ErrModifierDoesNotExist = TableError("grammar modifier does not exist")
+
+//MaxGrammarDepth is the most symbols that can be expanded within each other, it stops symbols that always expand to
+//themselves.
+const MaxGrammarDepth = 32
+
+//Grammar expands Tracery-style text using the tables in a library as its symbols. Each row of a table is one of
+//the symbol's expansions, rollable tables are rolled so their weights are kept and other tables pick a row at random.
+//The expansion is the first column that is not the roll column.
+//
+//	#name#                 expands the name table, any symbols in the expansion are expanded too
+//	#name.capitalize.s#    applies modifiers to the expansion in order
+//	[hero:#name#]          expands #name# and saves it, #hero# returns the saved text until [hero:POP]
+//	#[hero:#name#]story#   actions can be at the start of a tag
+//
+//Symbols can not contain a dot since it separates modifiers. The # [ ] and \ characters are escaped with a backslash.
+type Grammar struct {
+	library *Library
+	//Modifiers are the modifiers that can be used in tags, keyed by name. NewGrammar sets the DefaultModifiers.
+	Modifiers map[string]func(string) string
+}
+
+//DefaultModifiers are the English modifiers from Tracery.
+var DefaultModifiers = map[string]func(string) string{
+	"capitalize":    Capitalize,
+	"capitalizeAll": CapitalizeAll,
+	"s":             Pluralize,
+	"a":             Article,
+}
+
+//NewGrammar returns a Grammar using the tables in library as its symbols, with the DefaultModifiers.
+func NewGrammar(library *Library) *Grammar {
+	modifiers := make(map[string]func(string) string, len(DefaultModifiers))
+	for name, modifier := range DefaultModifiers {
+		modifiers[name] = modifier
+	}
+
+	return &Grammar{library: library, Modifiers: modifiers}
+}
+
+//Expand returns text with every tag expanded, using r for every roll. A nil r uses the dice package.
+//Saved symbols only last for a single expansion.
+func (g *Grammar) Expand(r Rand, text string) (string, error) {
+	s := &grammarState{grammar: g, library: g.library.Snapshot(), rand: r, saved: make(map[string][]string)}
+	return s.expand(text, 0)
+}
+
+//Flatten returns the expansion of symbol, the same as expanding "#symbol#".
+func (g *Grammar) Flatten(r Rand, symbol string) (string, error) {
+	return g.Expand(r, "#"+symbol+"#")
+}
+
+type grammarState struct {
+	grammar *Grammar
+	library *Library
+	rand    Rand
+	saved   map[string][]string
+}
+
+func (s *grammarState) expand(text string, depth int) (string, error) {
+	if depth > MaxGrammarDepth {
+		return "", ErrGrammarDepth
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if i+1 < len(text) {
+				i++
+			}
+			b.WriteByte(text[i])
+		case '[':
+			end := grammarClose(text, i+1, ']')
+			if end == -1 {
+				return "", fmt.Errorf("%w, unclosed action at offset %d", ErrInvalidGrammar, i)
+			}
+			err := s.action(text[i+1:end], depth)
+			if err != nil {
+				return "", err
+			}
+			i = end
+		case '#':
+			end := grammarClose(text, i+1, '#')
+			if end == -1 {
+				return "", fmt.Errorf("%w, unclosed tag at offset %d", ErrInvalidGrammar, i)
+			}
+			value, err := s.tag(text[i+1:end], depth)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			i = end
+		default:
+			b.WriteByte(text[i])
+		}
+	}
+
+	return b.String(), nil
+}
+
+//tag expands a tag, running any actions at its start before expanding its symbol and applying its modifiers.
+func (s *grammarState) tag(tag string, depth int) (string, error) {
+	for strings.HasPrefix(tag, "[") {
+		end := grammarClose(tag, 1, ']')
+		if end == -1 {
+			return "", fmt.Errorf("%w, unclosed action in #%s#", ErrInvalidGrammar, tag)
+		}
+		err := s.action(tag[1:end], depth)
+		if err != nil {
+			return "", err
+		}
+		tag = tag[end+1:]
+	}
+	if tag == "" {
+		return "", nil
+	}
+
+	parts := strings.Split(tag, ".")
+	value, err := s.symbol(parts[0], depth)
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range parts[1:] {
+		modifier, ok := s.grammar.Modifiers[name]
+		if !ok {
+			return "", fmt.Errorf("%s: %w", name, ErrModifierDoesNotExist)
+		}
+		value = modifier(value)
+	}
+
+	return value, nil
+}
+
+//action saves the expansion of an action's rule (e.g. [hero:#name#]), or removes the last saved rule for [hero:POP].
+func (s *grammarState) action(action string, depth int) error {
+	key, rule, found := strings.Cut(action, ":")
+	if !found || key == "" {
+		return fmt.Errorf("%w, action [%s] is not key:rule", ErrInvalidGrammar, action)
+	}
+
+	if rule == "POP" {
+		if saved := s.saved[key]; len(saved) > 0 {
+			s.saved[key] = saved[:len(saved)-1]
+		}
+		return nil
+	}
+
+	value, err := s.expand(rule, depth+1)
+	if err != nil {
+		return err
+	}
+	s.saved[key] = append(s.saved[key], value)
+
+	return nil
+}
+
+//symbol returns the expansion of a saved symbol, or of a random row from the table named symbol.
+func (s *grammarState) symbol(symbol string, depth int) (string, error) {
+	if saved := s.saved[symbol]; len(saved) > 0 {
+		return saved[len(saved)-1], nil
+	}
+
+	table, err := s.library.Resolve(symbol)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", symbol, err)
+	}
+
+	row, _, err := table.RandomRowWith(s.rand)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", symbol, err)
+	}
+
+	column := 0
+	if table.Meta.RollableTable && len(row) > 1 {
+		column = 1
+	}
+	if column >= len(row) {
+		return "", nil
+	}
+
+	return s.expand(row[column], depth+1)
+}
+
+//grammarClose returns the index of the closing character starting from start, skipping escaped characters and
+//nested actions, or -1 if it is not closed.
+func grammarClose(text string, start int, close byte) int {
+	brackets := 0
+	for i := start; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case text[i] == close && brackets == 0:
+			return i
+		case text[i] == '[':
+			brackets++
+		case text[i] == ']':
+			brackets--
+		}
+	}
+
+	return -1
+}
+
+//Capitalize returns value with its first letter in upper case.
+func Capitalize(value string) string {
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 {
+		return value
+	}
+
+	return string(unicode.ToUpper(r)) + value[size:]
+}
+
+//CapitalizeAll returns value with the first letter of every word in upper case.
+func CapitalizeAll(value string) string {
+	words := strings.Split(value, " ")
+	for i, word := range words {
+		words[i] = Capitalize(word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+//Pluralize returns the English plural of value using the same rules as Tracery (e.g. box to boxes, fly to flies).
+func Pluralize(value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch value[len(value)-1] {
+	case 's', 'h', 'x':
+		return value + "es"
+	case 'y':
+		if len(value) > 1 && !isVowel(value[len(value)-2]) {
+			return value[:len(value)-1] + "ies"
+		}
+	}
+
+	return value + "s"
+}
+
+//Article returns value with "a" or "an" before it, depending on whether it starts with a vowel.
+func Article(value string) string {
+	if value != "" && isVowel(value[0]) {
+		return "an " + value
+	}
+
+	return "a " + value
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiouAEIOU", c) >= 0
+}
+
+//LoadTracery returns a table for every symbol in a Tracery grammar, read as JSON from r. Each table is named after
+//its symbol and has a single column with one row for each of the symbol's rules, so it can be expanded with a Grammar.
+func LoadTracery(r io.Reader) ([]Table, error) {
+	var grammar map[string]json.RawMessage
+	err := json.NewDecoder(r).Decode(&grammar)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidGrammar, err)
+	}
+
+	var symbols []string
+	for symbol := range grammar {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	var tables []Table
+	for _, symbol := range symbols {
+		var rules []string
+		err := json.Unmarshal(grammar[symbol], &rules)
+		if err != nil {
+			//a symbol can have a single rule instead of a list
+			var rule string
+			if json.Unmarshal(grammar[symbol], &rule) != nil {
+				return nil, fmt.Errorf("%w, %s must be a rule or a list of rules", ErrInvalidGrammar, symbol)
+			}
+			rules = []string{rule}
+		}
+
+		records := [][]string{{symbol}}
+		for _, rule := range rules {
+			records = append(records, []string{rule})
+		}
+		table, err := Load(records, symbol, symbol, "")
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
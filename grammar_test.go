@@ -0,0 +1,146 @@
+package tables
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testGrammarLibrary() *Library {
+	animal, _ := Load([][]string{{"animal"}, {"owl"}}, "animal", "Animal", "")
+	color, _ := Load([][]string{{"D1", "color"}, {"1", "#shade# red"}}, "color", "Color", "d1")
+	shade, _ := Load([][]string{{"shade"}, {"deep"}}, "shade", "Shade", "")
+	name, _ := Load([][]string{{"name"}, {"Ayla"}, {"Bram"}}, "name", "Name", "")
+	loop, _ := Load([][]string{{"loop"}, {"#loop#"}}, "loop", "Loop", "")
+	return NewLibrary(animal, color, shade, name, loop)
+}
+
+func TestGrammar_Expand(t *testing.T) {
+	grammar := NewGrammar(testGrammarLibrary())
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "validate text without tags is unchanged", text: "a quiet night", want: []string{"a quiet night"}},
+		{name: "validate symbols are expanded from tables", text: "the #animal# hoots", want: []string{"the owl hoots"}},
+		{name: "validate expansions are expanded recursively from the first result column", text: "#color#", want: []string{"deep red"}},
+		{name: "validate modifiers are applied in order", text: "#animal.s.capitalize#, #animal.a#, #color.capitalizeAll#", want: []string{"Owls, an owl, Deep Red"}},
+		{name: "validate saved symbols are reused", text: "[hero:#name#]#hero# and #hero#", want: []string{"Ayla and Ayla", "Bram and Bram"}},
+		{name: "validate actions can start a tag", text: "#[hero:#animal#]hero.capitalize#", want: []string{"Owl"}},
+		{name: "validate saved symbols can be popped", text: "[animal:cat]#animal#[animal:POP] #animal#", want: []string{"cat owl"}},
+		{name: "validate characters can be escaped", text: `\#animal\# \[x\]`, want: []string{"#animal# [x]"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := grammar.Expand(NewRand(1), test.text)
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+			if !containsString(test.want, got) {
+				t.Errorf("want one of %v, got %s", test.want, got)
+			}
+		})
+	}
+
+	t.Run("validate custom modifiers can be added", func(t *testing.T) {
+		grammar := NewGrammar(testGrammarLibrary())
+		grammar.Modifiers["upper"] = strings.ToUpper
+
+		got, err := grammar.Flatten(nil, "animal.upper")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got != "OWL" {
+			t.Errorf("want OWL, got %s", got)
+		}
+	})
+
+	testErrors := []struct {
+		name string
+		text string
+		want error
+	}{
+		{name: "validate an error is returned for a symbol that is not a table", text: "#nope#", want: ErrTableDoesNotExist},
+		{name: "validate an error is returned for a modifier that does not exist", text: "#animal.nope#", want: ErrModifierDoesNotExist},
+		{name: "validate an error is returned for a symbol that expands forever", text: "#loop#", want: ErrGrammarDepth},
+		{name: "validate an error is returned for an unclosed tag", text: "the #animal", want: ErrInvalidGrammar},
+		{name: "validate an error is returned for an action without a rule", text: "[hero]", want: ErrInvalidGrammar},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := grammar.Expand(nil, test.text)
+			if !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestModifiers(t *testing.T) {
+	tests := []struct {
+		modifier func(string) string
+		value    string
+		want     string
+	}{
+		{modifier: Pluralize, value: "box", want: "boxes"},
+		{modifier: Pluralize, value: "church", want: "churches"},
+		{modifier: Pluralize, value: "fly", want: "flies"},
+		{modifier: Pluralize, value: "day", want: "days"},
+		{modifier: Pluralize, value: "sword", want: "swords"},
+		{modifier: Article, value: "elf", want: "an elf"},
+		{modifier: Article, value: "dwarf", want: "a dwarf"},
+		{modifier: Capitalize, value: "élan", want: "Élan"},
+		{modifier: CapitalizeAll, value: "the old mill", want: "The Old Mill"},
+	}
+
+	for _, test := range tests {
+		t.Run("validate modifier for "+test.value, func(t *testing.T) {
+			got := test.modifier(test.value)
+			if test.want != got {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadTracery(t *testing.T) {
+	t.Run("validate a table is loaded for every symbol", func(t *testing.T) {
+		got, err := LoadTracery(strings.NewReader(`{"origin": "#hero# the #class#", "hero": ["Ayla", "Bram"], "class": ["knight"]}`))
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		var names []string
+		for _, table := range got {
+			names = append(names, table.Meta.Name)
+		}
+		if want := []string{"class", "hero", "origin"}; !reflect.DeepEqual(want, names) {
+			t.Errorf("want %v, got %v", want, names)
+		}
+
+		want := [][]string{{"hero"}, {"Ayla"}, {"Bram"}}
+		if !reflect.DeepEqual(want, got[1].Records()) {
+			t.Errorf("want %v, got %v", want, got[1].Records())
+		}
+
+		expanded, err := NewGrammar(NewLibrary(got...)).Flatten(nil, "origin")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if expanded != "Ayla the knight" && expanded != "Bram the knight" {
+			t.Errorf("want a hero the knight, got %s", expanded)
+		}
+	})
+
+	t.Run("validate an error is returned for a grammar that is not valid", func(t *testing.T) {
+		_, err := LoadTracery(strings.NewReader(`{"origin": 5}`))
+		if !errors.Is(err, ErrInvalidGrammar) {
+			t.Errorf("want %v, got %v", ErrInvalidGrammar, err)
+		}
+	})
+}
@@ -0,0 +1,247 @@
+package tables
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const ErrRowLimit = TableError("table expression rolls more rows than allowed")
+
+//maxUploadSize limits the size of uploaded tables.
+const maxUploadSize = 1 << 20
+
+//MaxExpressionRows is the most rows a single table expression rolled by a server may roll (see ExpressionRows), it
+//keeps a client from asking for a million rows.
+const MaxExpressionRows = 1000
+
+//RollRequest is the body of a request to roll a table expression. A seed can be provided to repeat a roll.
+type RollRequest struct {
+	Expression string `json:"expression"`
+	Seed       *int64 `json:"seed,omitempty"`
+}
+
+//RollResponse is the result of rolling a table expression.
+type RollResponse struct {
+	Expression  string     `json:"expression"`
+	Table       string     `json:"table"`
+	DisplayName string     `json:"display_name"`
+	Headers     []string   `json:"headers"`
+	Results     [][]string `json:"results"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+//handler serves a library over HTTP.
+type handler struct {
+	library  *Library
+	audience Audience
+}
+
+//NewHandler returns an http.Handler serving a JSON API for the tables in library.
+//
+//	GET  /tables         lists the meta data of every table
+//	GET  /tables/{name}  returns the table resolved against its parents (see Resolve and Pack)
+//	POST /tables         adds a table, the body is decoded using the format query parameter (csv, markdown, json, or yaml)
+//	                     or Content-Type, and the table is named using the name and display_name query parameters
+//	POST /roll           rolls the table expression in a RollRequest, returning a RollResponse, expressions may roll
+//	                     at most MaxExpressionRows rows
+//
+//Errors are returned as a JSON object with an error field.
+func NewHandler(library *Library) http.Handler {
+	return NewHandlerFor(library, AudienceGM)
+}
+
+//NewHandlerFor returns an http.Handler serving the same API as NewHandler, showing tables and rolls as audience sees
+//them (see View). Only AudienceGM can add tables, other audiences are forbidden.
+func NewHandlerFor(library *Library, audience Audience) http.Handler {
+	return &handler{library: library, audience: audience}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "tables" && r.Method == http.MethodGet:
+		h.listTables(w)
+	case path == "tables" && r.Method == http.MethodPost:
+		h.addTable(w, r)
+	case strings.HasPrefix(path, "tables/") && r.Method == http.MethodGet:
+		h.getTable(w, strings.TrimPrefix(path, "tables/"))
+	case path == "roll" && r.Method == http.MethodPost:
+		h.roll(w, r)
+	case path == "tables" || strings.HasPrefix(path, "tables/") || path == "roll":
+		writeError(w, http.StatusMethodNotAllowed, errors.New(http.StatusText(http.StatusMethodNotAllowed)))
+	default:
+		writeError(w, http.StatusNotFound, errors.New(http.StatusText(http.StatusNotFound)))
+	}
+}
+
+func (h *handler) listTables(w http.ResponseWriter) {
+	library := h.library.Snapshot()
+	metas := []Meta{}
+	for _, name := range library.Names() {
+		//tables are resolved so children are shown with the columns they inherit, tables that can't be resolved are
+		//only listed for the gm since it isn't known what they would hide
+		table, err := library.Resolve(name)
+		if err != nil && h.audience != AudienceGM {
+			continue
+		}
+		if err != nil {
+			table, _ = library.Table(name)
+		}
+		metas = append(metas, table.View(h.audience).Meta)
+	}
+
+	writeJSON(w, http.StatusOK, metas)
+}
+
+func (h *handler) getTable(w http.ResponseWriter, name string) {
+	table, err := h.library.Resolve(name)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	_, b := table.View(h.audience).Pack()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
+
+func (h *handler) addTable(w http.ResponseWriter, r *http.Request) {
+	if h.audience != AudienceGM {
+		writeError(w, http.StatusForbidden, errors.New(http.StatusText(http.StatusForbidden)))
+		return
+	}
+
+	query := r.URL.Query()
+	name := query.Get("name")
+
+	format, ok := requestFormat(r)
+	if !ok {
+		writeError(w, http.StatusUnsupportedMediaType, ErrUnsupportedFormat)
+		return
+	}
+
+	table, err := Decode(http.MaxBytesReader(w, r.Body, maxUploadSize), format, name)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if displayName := query.Get("display_name"); displayName != "" {
+		table.Meta.DisplayName = displayName
+	}
+
+	if table.Meta.Name == "" || ParseTablename("?"+table.Meta.Name) != table.Meta.Name {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w, a name that can be used in table expressions is required", ErrTableInvalid))
+		return
+	}
+
+	err = table.Validate()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.library.Add(table)
+
+	writeJSON(w, http.StatusCreated, table)
+}
+
+func (h *handler) roll(w http.ResponseWriter, r *http.Request) {
+	var request RollRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, maxUploadSize)).Decode(&request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if ExpressionRows(request.Expression) > MaxExpressionRows {
+		writeError(w, http.StatusBadRequest, ErrRowLimit)
+		return
+	}
+
+	var rand Rand
+	if request.Seed != nil {
+		rand = NewRand(*request.Seed)
+	}
+
+	library := h.library.Snapshot()
+	records, err := library.ExpressionWith(rand, request.Expression)
+	table, _ := library.Resolve(ParseTablename(request.Expression))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	records = table.ViewRecords(records, h.audience)
+	writeJSON(w, http.StatusOK, RollResponse{
+		Expression:  request.Expression,
+		Table:       table.Meta.Name,
+		DisplayName: table.Meta.DisplayName,
+		Headers:     records[0],
+		Results:     records[1:],
+	})
+}
+
+//requestFormat returns the format of an uploaded table, using the format query parameter or the request's Content-Type.
+func requestFormat(r *http.Request) (Format, bool) {
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		return FormatCSV, true
+	case "markdown", "md":
+		return FormatMarkdown, true
+	case "json":
+		return FormatJSON, true
+	case "yaml", "yml":
+		return FormatYAML, true
+	case "":
+	default:
+		return "", false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV, true
+	case "text/markdown":
+		return FormatMarkdown, true
+	case "application/json":
+		return FormatJSON, true
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return FormatYAML, true
+	}
+
+	return "", false
+}
+
+//statusFor returns the HTTP status code for an error returned by a library.
+func statusFor(err error) int {
+	if errors.Is(err, ErrTableDoesNotExist) {
+		return http.StatusNotFound
+	}
+
+	var tableErr TableError
+	if errors.As(err, &tableErr) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
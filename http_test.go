@@ -0,0 +1,278 @@
+package tables
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	encounters, _ := Load(testCSV, "encounters", "Encounters", "d6")
+	abilities, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+	server := httptest.NewServer(NewHandler(NewLibrary(encounters, abilities)))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func doRequest(t *testing.T, method, url, contentType, body string, value interface{}) int {
+	request, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	defer response.Body.Close()
+
+	if got := response.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("want application/json, got %s", got)
+	}
+
+	if value != nil {
+		err = json.NewDecoder(response.Body).Decode(value)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+	}
+
+	return response.StatusCode
+}
+
+func TestHandler_tables(t *testing.T) {
+	server := newTestServer(t)
+
+	t.Run("validate tables are listed", func(t *testing.T) {
+		var got []Meta
+		status := doRequest(t, http.MethodGet, server.URL+"/tables", "", "", &got)
+
+		if status != http.StatusOK {
+			t.Errorf("want %d, got %d", http.StatusOK, status)
+		}
+		if len(got) != 2 || got[0].Name != "abilities" || got[1].Name != "encounters" {
+			t.Errorf("want abilities and encounters, got %v", got)
+		}
+	})
+
+	t.Run("validate a table is returned", func(t *testing.T) {
+		var got Table
+		status := doRequest(t, http.MethodGet, server.URL+"/tables/encounters", "", "", &got)
+
+		if status != http.StatusOK {
+			t.Errorf("want %d, got %d", http.StatusOK, status)
+		}
+		want, _ := Load(testCSV, "encounters", "Encounters", "d6")
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate not found is returned for a table that is not in the library", func(t *testing.T) {
+		var got errorResponse
+		status := doRequest(t, http.MethodGet, server.URL+"/tables/nope", "", "", &got)
+
+		if status != http.StatusNotFound {
+			t.Errorf("want %d, got %d", http.StatusNotFound, status)
+		}
+		if got.Error != ErrTableDoesNotExist.Error() {
+			t.Errorf("want %s, got %s", ErrTableDoesNotExist, got.Error)
+		}
+	})
+
+	t.Run("validate a csv table is uploaded", func(t *testing.T) {
+		var got Table
+		status := doRequest(t, http.MethodPost, server.URL+"/tables?name=weather&display_name=Weather", "text/csv", "D4,Weather\n1-3,Sunny\n4,Rain\n", &got)
+
+		if status != http.StatusCreated {
+			t.Errorf("want %d, got %d", http.StatusCreated, status)
+		}
+		if got.Meta.Name != "weather" || got.Meta.DisplayName != "Weather" || got.Meta.RollExpression != "d4" {
+			t.Errorf("want weather, Weather, and d4, got %v", got.Meta)
+		}
+
+		var roll RollResponse
+		status = doRequest(t, http.MethodPost, server.URL+"/roll", "application/json", `{"expression":"4#weather"}`, &roll)
+		if status != http.StatusOK {
+			t.Errorf("want %d, got %d", http.StatusOK, status)
+		}
+		if !reflect.DeepEqual([][]string{{"4", "Rain"}}, roll.Results) {
+			t.Errorf("want [[4 Rain]], got %v", roll.Results)
+		}
+	})
+
+	t.Run("validate a markdown table is uploaded", func(t *testing.T) {
+		var got Table
+		status := doRequest(t, http.MethodPost, server.URL+"/tables?name=forest&format=markdown", "", markdownTable, &got)
+
+		if status != http.StatusCreated {
+			t.Errorf("want %d, got %d", http.StatusCreated, status)
+		}
+		if got.Meta.DisplayName != "Forest Encounters" || len(got.Rows) != 3 {
+			t.Errorf("want Forest Encounters with 3 rows, got %v", got)
+		}
+	})
+
+	testErrors := []struct {
+		name        string
+		url         string
+		contentType string
+		body        string
+		want        int
+	}{
+		{name: "validate an error is returned without a name", url: "/tables", contentType: "text/csv", body: "D4,Weather\n1-4,Sunny\n", want: http.StatusBadRequest},
+		{name: "validate an error is returned for a name that can't be used in table expressions", url: "/tables?name=bad%20name", contentType: "text/csv", body: "D4,Weather\n1-4,Sunny\n", want: http.StatusBadRequest},
+		{name: "validate an error is returned for an invalid table", url: "/tables?name=bad", contentType: "text/csv", body: "D4,Weather\n1-3,Sunny\n2-4,Rain\n", want: http.StatusBadRequest},
+		{name: "validate an error is returned for an unsupported format", url: "/tables?name=bad", contentType: "application/xml", body: "<table/>", want: http.StatusUnsupportedMediaType},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			var got errorResponse
+			status := doRequest(t, http.MethodPost, server.URL+test.url, test.contentType, test.body, &got)
+
+			if status != test.want {
+				t.Errorf("want %d, got %d (%s)", test.want, status, got.Error)
+			}
+			if got.Error == "" {
+				t.Errorf("expected an error message")
+			}
+		})
+	}
+}
+
+func TestHandler_roll(t *testing.T) {
+	server := newTestServer(t)
+
+	t.Run("validate a table expression is rolled", func(t *testing.T) {
+		var got RollResponse
+		status := doRequest(t, http.MethodPost, server.URL+"/roll", "application/json", `{"expression":"3#encounters"}`, &got)
+
+		if status != http.StatusOK {
+			t.Errorf("want %d, got %d", http.StatusOK, status)
+		}
+		want := RollResponse{Expression: "3#encounters", Table: "encounters", DisplayName: "Encounters", Headers: testCSV[0], Results: [][]string{testCSV[3]}}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate seeded rolls are repeated", func(t *testing.T) {
+		var want, got RollResponse
+		doRequest(t, http.MethodPost, server.URL+"/roll", "application/json", `{"expression":"5?encounters","seed":12}`, &want)
+		doRequest(t, http.MethodPost, server.URL+"/roll", "application/json", `{"expression":"5?encounters","seed":12}`, &got)
+
+		if len(got.Results) != 5 {
+			t.Errorf("want 5, got %d", len(got.Results))
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	testErrors := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "validate an error is returned for an invalid body", body: `{"expression":`, want: http.StatusBadRequest},
+		{name: "validate an error is returned for an invalid table expression", body: `{"expression":"encounters"}`, want: http.StatusBadRequest},
+		{name: "validate an error is returned for a table that is not rollable", body: `{"expression":"2?abilities"}`, want: http.StatusBadRequest},
+		{name: "validate an error is returned for a table that is not in the library", body: `{"expression":"2?nope"}`, want: http.StatusNotFound},
+		{name: "validate an error is returned for too many rows", body: `{"expression":"200000?encounters"}`, want: http.StatusBadRequest},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			var got errorResponse
+			status := doRequest(t, http.MethodPost, server.URL+"/roll", "application/json", test.body, &got)
+
+			if status != test.want {
+				t.Errorf("want %d, got %d (%s)", test.want, status, got.Error)
+			}
+		})
+	}
+
+	t.Run("validate an error is returned for an unsupported method", func(t *testing.T) {
+		status := doRequest(t, http.MethodGet, server.URL+"/roll", "", "", nil)
+		if status != http.StatusMethodNotAllowed {
+			t.Errorf("want %d, got %d", http.StatusMethodNotAllowed, status)
+		}
+	})
+
+	t.Run("validate an error is returned for an unknown path", func(t *testing.T) {
+		status := doRequest(t, http.MethodGet, server.URL+"/nope", "", "", nil)
+		if status != http.StatusNotFound {
+			t.Errorf("want %d, got %d", http.StatusNotFound, status)
+		}
+	})
+}
+
+func TestNewHandlerFor(t *testing.T) {
+	traps := testTrapsTable(t)
+	server := httptest.NewServer(NewHandlerFor(NewLibrary(traps, testDeepTrapsTable(t)), AudiencePlayer))
+	t.Cleanup(server.Close)
+
+	t.Run("validate hidden columns are not listed", func(t *testing.T) {
+		var got []Meta
+		doRequest(t, http.MethodGet, server.URL+"/tables", "", "", &got)
+
+		if len(got) != 2 {
+			t.Fatalf("want deep_traps and traps, got %v", got)
+		}
+		for _, meta := range got {
+			if !reflect.DeepEqual([]string{"D3", "Trap", "Effect"}, meta.Headers) {
+				t.Errorf("want the player's headers, got %v", meta.Headers)
+			}
+		}
+	})
+
+	t.Run("validate hidden columns inherited by a child are not returned", func(t *testing.T) {
+		var got Table
+		doRequest(t, http.MethodGet, server.URL+"/tables/deep_traps", "", "", &got)
+
+		want := [][]string{{"D3", "Trap", "Effect"}, {"1", "Pit", "{{1d1}}0 ft fall"}, {"2", "Needles", "Sleep"}, {"3", "Rune", "Fire"}}
+		if !reflect.DeepEqual(want, got.Records()) {
+			t.Errorf("want %v, got %v", want, got.Records())
+		}
+	})
+
+	t.Run("validate hidden columns are not returned", func(t *testing.T) {
+		var got Table
+		doRequest(t, http.MethodGet, server.URL+"/tables/traps", "", "", &got)
+
+		want := traps.View(AudiencePlayer)
+		if !reflect.DeepEqual(want.Records(), got.Records()) {
+			t.Errorf("want %v, got %v", want.Records(), got.Records())
+		}
+		if got.Rows[0].Meta != nil {
+			t.Errorf("want no notes, got %v", got.Rows[0].Meta)
+		}
+	})
+
+	t.Run("validate hidden columns are not rolled", func(t *testing.T) {
+		var got RollResponse
+		doRequest(t, http.MethodPost, server.URL+"/roll", "application/json", `{"expression":"3#traps"}`, &got)
+
+		want := RollResponse{Expression: "3#traps", Table: "traps", DisplayName: "Traps", Headers: []string{"D3", "Trap", "Effect"}, Results: [][]string{{"3", "Rune", "Fire"}}}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate tables can not be added", func(t *testing.T) {
+		var got errorResponse
+		status := doRequest(t, http.MethodPost, server.URL+"/tables?name=weather", "text/csv", "Weather\nRain\n", &got)
+
+		if status != http.StatusForbidden {
+			t.Errorf("want %d, got %d", http.StatusForbidden, status)
+		}
+	})
+}
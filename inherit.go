@@ -0,0 +1,300 @@
+package tables
+
+import (
+	"slices"
+	"strings"
+)
+
+const ErrInheritanceCycle = TableError("table inherits from itself")
+const ErrMergeConflict = TableError("tables do not have the same column types, visibility, or translated headers")
+const ErrInvalidRowAction = TableError("row action must be override, append, or remove")
+
+//Row actions control how the rows of a table with a parent are combined with its parent's rows (see Inherit).
+const (
+	RowOverride = "override"
+	RowAppend   = "append"
+	RowRemove   = "remove"
+)
+
+//Inherit returns child resolved against its parent. Meta data the child does not set (display name, title, flavor
+//text, headers, column types and visibility, roll expression, and locales) is taken from the parent. Each child row is matched to a parent row by
+//its roll for rollable tables, or by its first column for other tables, and then applied using its action:
+//
+//	override  replaces the matching parent row, ErrRowDoesNotExist is returned if there isn't one
+//	append    adds the row to the table
+//	remove    removes the matching parent row, only the row's key needs to be set
+//
+//A row without an action overrides the matching parent row, or is appended if there isn't one. The resolved table
+//has no parent or row actions, and is validated before it is returned.
+func Inherit(parent, child Table) (Table, error) {
+	meta := child.Meta
+	meta.Parent = ""
+	meta.DisplayName = firstNonEmpty(meta.DisplayName, parent.Meta.DisplayName)
+	meta.Title = firstNonEmpty(meta.Title, parent.Meta.Title)
+	meta.FlavorText = firstNonEmpty(meta.FlavorText, parent.Meta.FlavorText)
+	if len(meta.Headers) == 0 {
+		meta.Headers = parent.Meta.Headers
+	}
+	if meta.ColumnTypes == nil {
+		meta.ColumnTypes = parent.Meta.ColumnTypes
+	}
+	if meta.Visibility == nil {
+		meta.Visibility = parent.Meta.Visibility
+	}
+	if meta.RollExpression == "" {
+		meta.RollExpression = parent.Meta.RollExpression
+		meta.RollableTable = parent.Meta.RollableTable
+	}
+	if meta.Locales == nil {
+		meta.Locales = parent.Meta.Locales
+	}
+	meta.ColumnCount = len(meta.Headers)
+
+	if meta.RollableTable != parent.Meta.RollableTable {
+		return Table{}, ErrTableInvalid
+	}
+	if meta.ColumnCount != parent.Meta.ColumnCount {
+		return Table{}, ErrInvalidColumnCount
+	}
+
+	rows := append([]Row(nil), parent.Rows...)
+	for _, row := range child.Rows {
+		index := -1
+		for i, existing := range rows {
+			if inheritKey(existing, meta.RollableTable) == inheritKey(row, meta.RollableTable) {
+				index = i
+				break
+			}
+		}
+
+		action := row.Action
+		row.Action = ""
+		switch {
+		case action == RowRemove || (action == RowOverride && index < 0):
+			if index < 0 {
+				return Table{}, ErrRowDoesNotExist
+			}
+			rows = append(rows[:index], rows[index+1:]...)
+		case action == RowOverride || (action == "" && index >= 0):
+			rows[index] = row
+		case action == RowAppend || action == "":
+			rows = append(rows, row)
+		default:
+			return Table{}, ErrInvalidRowAction
+		}
+	}
+
+	table := Table{Meta: meta, Rows: rows}
+	if meta.RollableTable {
+		sortRows(table.Rows)
+	}
+	table.renumberPositions()
+
+	err := table.Validate()
+	if err != nil {
+		return Table{}, err
+	}
+
+	return table, nil
+}
+
+//Resolve returns the table with the provided name with its parents resolved (see Inherit), a table without a parent
+//is returned as it is. ErrInheritanceCycle is returned if a table is its own ancestor.
+func (l *Library) Resolve(name string) (Table, error) {
+	return resolve(l.snapshot(), name, nil)
+}
+
+func resolve(tables map[string]Table, name string, children []string) (Table, error) {
+	table, ok := tables[name]
+	if !ok {
+		return Table{}, ErrTableDoesNotExist
+	}
+	if table.Meta.Parent == "" {
+		return table, nil
+	}
+
+	for _, child := range children {
+		if child == name {
+			return Table{}, ErrInheritanceCycle
+		}
+	}
+
+	parent, err := resolve(tables, table.Meta.Parent, append(children, name))
+	if err != nil {
+		return Table{}, err
+	}
+
+	return Inherit(parent, table)
+}
+
+//inheritKey returns the key used to match a child row to its parent's row.
+func inheritKey(row Row, rollable bool) string {
+	if rollable {
+		return RowKey(row)
+	}
+	if len(row.Results) == 0 {
+		return ""
+	}
+
+	return row.Results[0]
+}
+
+//Merge combines tables into a single rollable table named name, giving each row a range of rollExpression so that
+//rolling it is as close as possible to picking one of the tables at random and then rolling it. Every table must
+//have the same number of result columns (not counting the roll column of rollable tables), the headers of the first
+//table are used. The column types, visibility, and translated headers of the tables are kept, and ErrMergeConflict
+//is returned if they are not the same for every table. ErrNotEnoughRollValues is returned if rollExpression can't
+//give every row a value.
+func Merge(name, rollExpression string, tables ...Table) (Table, error) {
+	if len(tables) == 0 {
+		return Table{}, ErrTableInvalid
+	}
+
+	headers := []string{strings.ToUpper(rollExpression)}
+	headers = append(headers, resultColumns(tables[0].Meta, tables[0].Meta.Headers)...)
+
+	var rows []Row
+	var want []float64
+	for _, table := range tables {
+		if len(resultColumns(table.Meta, table.Meta.Headers)) != len(headers)-1 {
+			return Table{}, ErrInvalidColumnCount
+		}
+
+		probabilities, err := table.Probabilities()
+		if err != nil {
+			return Table{}, err
+		}
+		total := 0.0
+		for _, probability := range probabilities {
+			total += probability
+		}
+
+		for i, row := range table.Rows {
+			results := resultColumns(table.Meta, row.Results)
+			if len(results) != len(headers)-1 {
+				return Table{}, ErrInvalidColumnCount
+			}
+
+			probability := 1 / float64(len(table.Rows))
+			if total > 0 {
+				probability = probabilities[i] / total
+			}
+			want = append(want, probability/float64(len(tables)))
+
+			var locales map[string][]string
+			for locale, translation := range row.Locales {
+				if locales == nil {
+					locales = make(map[string][]string, len(row.Locales))
+				}
+				locales[locale] = append([]string{""}, resultColumns(table.Meta, translation)...)
+			}
+
+			record := append([]string{""}, results...)
+			rows = append(rows, Row{HasRollExpression: row.HasRollExpression, Results: record, Locales: locales, Meta: row.Meta})
+		}
+	}
+
+	columnTypes, err := mergeColumns(tables, func(meta Meta) []ColumnType { return meta.ColumnTypes })
+	if err != nil {
+		return Table{}, err
+	}
+	visibility, err := mergeColumns(tables, func(meta Meta) []Audience { return meta.Visibility })
+	if err != nil {
+		return Table{}, err
+	}
+	locales, err := mergeLocales(tables, headers[0])
+	if err != nil {
+		return Table{}, err
+	}
+
+	//tables without rows leave some probability unused, spread it across the other rows
+	total := 0.0
+	for _, probability := range want {
+		total += probability
+	}
+	for i := range want {
+		want[i] /= total
+	}
+
+	rows, _, err = assignRolls(rows, want, rollExpression)
+	if err != nil {
+		return Table{}, err
+	}
+
+	return Table{
+		Meta: Meta{
+			Name:           name,
+			Headers:        headers,
+			ColumnTypes:    columnTypes,
+			Visibility:     visibility,
+			Locales:        locales,
+			ColumnCount:    len(headers),
+			RollableTable:  true,
+			RollExpression: rollExpression,
+		},
+		Rows: rows,
+	}, nil
+}
+
+//mergeColumns returns the per column values of the merged table for the values returned by columns, which must be
+//the same for the result columns of every table. A table without values has an empty value for each column, and nil
+//is returned if every value is empty.
+func mergeColumns[T ~string](tables []Table, columns func(meta Meta) []T) ([]T, error) {
+	var merged []T
+	for i, table := range tables {
+		values := make([]T, len(table.Meta.Headers))
+		copy(values, columns(table.Meta))
+		if table.Meta.RollableTable && len(values) > 0 {
+			values = values[1:]
+		}
+
+		if i > 0 && !slices.Equal(merged, values) {
+			return nil, ErrMergeConflict
+		}
+		merged = values
+	}
+
+	for _, value := range merged {
+		if value != "" {
+			return append([]T{""}, merged...), nil
+		}
+	}
+
+	return nil, nil
+}
+
+//mergeLocales returns the translated headers of the merged table, a locale's headers must be the same for the result
+//columns of every table that translates them. The roll column keeps rollHeader since it is never translated.
+func mergeLocales(tables []Table, rollHeader string) (map[string]MetaLocale, error) {
+	var merged map[string]MetaLocale
+	for _, table := range tables {
+		for locale, meta := range table.Meta.Locales {
+			if meta.Headers == nil {
+				continue
+			}
+			headers := append([]string{rollHeader}, resultColumns(table.Meta, meta.Headers)...)
+			if previous, ok := merged[locale]; ok {
+				if !slices.Equal(previous.Headers, headers) {
+					return nil, ErrMergeConflict
+				}
+				continue
+			}
+
+			if merged == nil {
+				merged = make(map[string]MetaLocale)
+			}
+			merged[locale] = MetaLocale{Headers: headers}
+		}
+	}
+
+	return merged, nil
+}
+
+//resultColumns returns the values of record without the roll column of a rollable table.
+func resultColumns(meta Meta, record []string) []string {
+	if meta.RollableTable && len(record) > 0 {
+		return record[1:]
+	}
+
+	return record
+}
@@ -0,0 +1,247 @@
+package tables
+
+import (
+	"reflect"
+	"testing"
+)
+
+var coreEncountersCSV = [][]string{
+	{"D6", "Encounter"},
+	{"1-2", "Goblins"},
+	{"3", "Wolves"},
+	{"4", "Bandits"},
+	{"5-6", "Nothing"},
+}
+
+func testInheritLibrary(t *testing.T) *Library {
+	core, err := Load(coreEncountersCSV, "encounters", "Encounters", "d6")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	core.Meta.FlavorText = "Trouble on the road."
+
+	swamp, err := Load([][]string{{"D6", "Encounter"}, {"3", "Bog wights"}, {"4"}}, "swamp", "Swamp Encounters", "d6")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	swamp.Meta.Parent = "encounters"
+	swamp.Rows[1].Action = RowRemove
+
+	deepSwamp := Table{Meta: Meta{Name: "deep_swamp", Parent: "swamp"}, Rows: []Row{{DieRoll: 4, Results: []string{"4", "Hydra"}, Action: RowAppend}}}
+	loopA := Table{Meta: Meta{Name: "loop_a", Parent: "loop_b"}}
+	loopB := Table{Meta: Meta{Name: "loop_b", Parent: "loop_a"}}
+
+	return NewLibrary(core, swamp, deepSwamp, loopA, loopB)
+}
+
+func TestLibrary_Resolve(t *testing.T) {
+	library := testInheritLibrary(t)
+
+	t.Run("validate a table without a parent is returned as it is", func(t *testing.T) {
+		want, _ := library.Table("encounters")
+		got, err := library.Resolve("encounters")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate a child inherits its parent's meta data and rows", func(t *testing.T) {
+		got, err := library.Resolve("swamp")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		want := [][]string{{"D6", "Encounter"}, {"1-2", "Goblins"}, {"3", "Bog wights"}, {"5-6", "Nothing"}}
+		if !reflect.DeepEqual(want, got.Records()) {
+			t.Errorf("want %v, got %v", want, got.Records())
+		}
+		if got.Meta.DisplayName != "Swamp Encounters" || got.Meta.FlavorText != "Trouble on the road." || got.Meta.RollExpression != "d6" || got.Meta.Parent != "" {
+			t.Errorf("want inherited meta data, got %v", got.Meta)
+		}
+	})
+
+	t.Run("validate parents are resolved through every ancestor", func(t *testing.T) {
+		got, err := library.Resolve("deep_swamp")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		want := [][]string{{"D6", "Encounter"}, {"1-2", "Goblins"}, {"3", "Bog wights"}, {"4", "Hydra"}, {"5-6", "Nothing"}}
+		if !reflect.DeepEqual(want, got.Records()) {
+			t.Errorf("want %v, got %v", want, got.Records())
+		}
+		for _, row := range got.Rows {
+			if row.Action != "" {
+				t.Errorf("want no row actions, got %s", row.Action)
+			}
+		}
+	})
+
+	t.Run("validate table expressions are rolled against the resolved table", func(t *testing.T) {
+		got, err := library.Expression("3#swamp")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := [][]string{{"D6", "Encounter"}, {"3", "Bog wights"}}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	testErrors := []struct {
+		name  string
+		table string
+		want  error
+	}{
+		{name: "validate an error is returned for a cycle", table: "loop_a", want: ErrInheritanceCycle},
+		{name: "validate an error is returned for a table that is not in the library", table: "nope", want: ErrTableDoesNotExist},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := library.Resolve(test.table)
+			if err != test.want {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestInherit(t *testing.T) {
+	parent, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+
+	t.Run("validate rows of tables that are not rollable are matched by their first column", func(t *testing.T) {
+		child := Table{Meta: Meta{Name: "house_abilities"}, Rows: []Row{
+			{Results: []string{"BTR", "Bitterness."}},
+			{Results: []string{"CRB"}, Action: RowRemove},
+			{Results: []string{"LCK", "Luck."}},
+		}}
+
+		got, err := Inherit(parent, child)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		want := [][]string{nonRollableCSV[0], nonRollableCSV[1], {"BTR", "Bitterness."}, {"LCK", "Luck."}}
+		if !reflect.DeepEqual(want, got.Records()) {
+			t.Errorf("want %v, got %v", want, got.Records())
+		}
+		for i, row := range got.Rows {
+			if row.DieRoll != i+1 {
+				t.Errorf("want %d, got %d", i+1, row.DieRoll)
+			}
+		}
+	})
+
+	testErrors := []struct {
+		name  string
+		child Table
+		want  error
+	}{
+		{name: "validate an error is returned for overriding a row that does not exist", child: Table{Rows: []Row{{Results: []string{"LCK", "Luck."}, Action: RowOverride}}}, want: ErrRowDoesNotExist},
+		{name: "validate an error is returned for removing a row that does not exist", child: Table{Rows: []Row{{Results: []string{"LCK"}, Action: RowRemove}}}, want: ErrRowDoesNotExist},
+		{name: "validate an error is returned for an unknown action", child: Table{Rows: []Row{{Results: []string{"FUN", "Fun."}, Action: "replace"}}}, want: ErrInvalidRowAction},
+		{name: "validate an error is returned for rows with the wrong columns", child: Table{Rows: []Row{{Results: []string{"LCK"}}}}, want: ErrInvalidColumnCount},
+		{name: "validate an error is returned for different columns", child: Table{Meta: Meta{Headers: []string{"Ability"}}}, want: ErrInvalidColumnCount},
+		{name: "validate an error is returned for a rollable child", child: Table{Meta: Meta{RollExpression: "d4", RollableTable: true}}, want: ErrTableInvalid},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Inherit(parent, test.child)
+			if err != test.want {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	encounters, _ := Load(coreEncountersCSV, "encounters", "Encounters", "d6")
+	weather, _ := Load([][]string{{"Weather"}, {"Rain"}, {"Fog"}}, "weather", "Weather", "")
+
+	t.Run("validate tables are merged with rescaled ranges", func(t *testing.T) {
+		got, err := Merge("events", "d12", encounters, weather)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		want := [][]string{{"D12", "Encounter"}, {"1-2", "Goblins"}, {"3", "Wolves"}, {"4", "Bandits"}, {"5-6", "Nothing"}, {"7-9", "Rain"}, {"10-12", "Fog"}}
+		if !reflect.DeepEqual(want, got.Records()) {
+			t.Errorf("want %v, got %v", want, got.Records())
+		}
+
+		err = got.Validate()
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		rows, err := got.Expression("10#events")
+		if err != nil || rows[1][1] != "Fog" {
+			t.Errorf("want Fog, got %v (%v)", rows, err)
+		}
+	})
+
+	traps, _ := Load(trapsCSV, "traps", "Traps", "d3")
+	lair, _ := Load([][]string{{"Trap", "!Trap DC:int", "Effect"}, {"Blade", "14", "Bleed"}}, "lair", "Lair", "")
+	err := lair.AddLocale("es", [][]string{{"Trampa", "CD", "Efecto"}, {"Cuchilla", "14", "Sangrado"}}, "Guarida")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	retranslated := lair.Clone()
+	err = retranslated.AddLocale("es", [][]string{{"Trampa", "Dificultad", "Efecto"}, {"Cuchilla", "14", "Sangrado"}}, "Guarida")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	t.Run("validate column types, visibility, and locales are kept", func(t *testing.T) {
+		got, err := Merge("all_traps", "d8", traps, lair)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		if want := []Audience{"", "", AudienceGM, ""}; !reflect.DeepEqual(want, got.Meta.Visibility) {
+			t.Errorf("want %v, got %v", want, got.Meta.Visibility)
+		}
+		if got.ColumnType(2) != ColumnInt {
+			t.Errorf("want %s, got %s", ColumnInt, got.ColumnType(2))
+		}
+		want := [][]string{{"D8", "Trap", "Effect"}, {"1", "Pit", "{{1d1}}0 ft fall"}, {"2", "Darts", "Poison"}, {"3-4", "Rune", "Fire"}, {"5-8", "Blade", "Bleed"}}
+		if player := got.View(AudiencePlayer); !reflect.DeepEqual(want, player.Records()) {
+			t.Errorf("want %v, got %v", want, player.Records())
+		}
+		want = [][]string{{"D8", "Trampa", "CD", "Efecto"}, {"5-8", "Cuchilla", "14", "Sangrado"}}
+		if localized := got.Localize("es").Records(); !reflect.DeepEqual(want, [][]string{localized[0], localized[4]}) {
+			t.Errorf("want %v, got %v", want, [][]string{localized[0], localized[4]})
+		}
+		err = got.Validate()
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+	})
+
+	testErrors := []struct {
+		name           string
+		rollExpression string
+		tables         []Table
+		want           error
+	}{
+		{name: "validate an error is returned without tables", rollExpression: "d6", want: ErrTableInvalid},
+		{name: "validate an error is returned for different result columns", rollExpression: "d20", tables: []Table{encounters, {Meta: Meta{Headers: []string{"A", "B"}}}}, want: ErrInvalidColumnCount},
+		{name: "validate an error is returned for too few roll values", rollExpression: "d4", tables: []Table{encounters, weather}, want: ErrNotEnoughRollValues},
+		{name: "validate an error is returned for different visibility", rollExpression: "d20", tables: []Table{traps, {Meta: Meta{Headers: []string{"A", "B", "C"}}}}, want: ErrMergeConflict},
+		{name: "validate an error is returned for different translated headers", rollExpression: "d20", tables: []Table{lair, retranslated}, want: ErrMergeConflict},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Merge("events", test.rollExpression, test.tables...)
+			if err != test.want {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
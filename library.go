@@ -0,0 +1,214 @@
+package tables
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+//Library is a collection of tables, keyed by name, that table expressions can be rolled against.
+//A library is safe for concurrent use. Reads use an immutable snapshot of the tables and never block, while
+//changes copy the snapshot and atomically replace it, so they are best suited to tables that are read far more
+//often than they are changed.
+type Library struct {
+	//mu serializes changes, readers never lock it
+	mu    sync.Mutex
+	state atomic.Pointer[libraryState]
+}
+
+//libraryState is the tables of a library and their search index (see Search). They are replaced together, so readers
+//always search the tables they read.
+type libraryState struct {
+	tables map[string]Table
+	index  *searchIndex
+}
+
+//NewLibrary returns a library containing the provided tables.
+func NewLibrary(tables ...Table) *Library {
+	l := &Library{}
+	l.Replace(tables...)
+
+	return l
+}
+
+//LoadLibrary returns a library containing every table in dir and its subdirectories (see LoadDir).
+func LoadLibrary(dir string) (*Library, error) {
+	tables, err := LoadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLibrary(tables...), nil
+}
+
+//Add adds a copy of the table to the library, replacing any table with the same name.
+func (l *Library) Add(table Table) {
+	table = table.Clone()
+	l.update(table.Meta.Name, func(tables map[string]Table) {
+		tables[table.Meta.Name] = table
+	})
+}
+
+//Remove removes the table with the provided name from the library.
+func (l *Library) Remove(name string) {
+	l.update(name, func(tables map[string]Table) {
+		delete(tables, name)
+	})
+}
+
+//Replace replaces every table in the library with copies of the provided tables in a single change, readers see
+//either all of the previous tables or all of the new ones.
+func (l *Library) Replace(tables ...Table) {
+	next := make(map[string]Table, len(tables))
+	for _, table := range tables {
+		next[table.Meta.Name] = table.Clone()
+	}
+	index := &searchIndex{}
+	for name := range next {
+		index.add(next, name)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.state.Store(&libraryState{tables: next, index: index})
+}
+
+//Update replaces the table with the provided name with the table returned by f, which is given the current table.
+//No other change can be made to the library while f runs, so changes made by f are never lost to a concurrent
+//change. Nothing is changed if f returns an error, and ErrTableInvalid is returned if f renames the table.
+func (l *Library) Update(name string, f func(table Table) (Table, error)) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current := l.snapshot()
+	table, ok := current[name]
+	if !ok {
+		return ErrTableDoesNotExist
+	}
+
+	table, err := f(table.Clone())
+	if err != nil {
+		return err
+	}
+	if table.Meta.Name != name {
+		return ErrTableInvalid
+	}
+
+	next := copyTables(current)
+	next[name] = table
+	index := l.index().copy()
+	index.update(next, name)
+	l.state.Store(&libraryState{tables: next, index: index})
+
+	return nil
+}
+
+//Snapshot returns a library containing the tables in l at the time it is called, later changes to l do not change
+//it. Use a snapshot when several reads need to see the same tables, e.g. rolling a table and then showing its name.
+func (l *Library) Snapshot() *Library {
+	s := &Library{}
+	s.state.Store(l.state.Load())
+
+	return s
+}
+
+//Table returns the table with the provided name, ErrTableDoesNotExist is returned if it is not in the library.
+//The returned table shares its rows with the library, so it must not be changed in place. The table editing
+//methods copy what they change and can be used, as can a Clone.
+func (l *Library) Table(name string) (Table, error) {
+	table, ok := l.snapshot()[name]
+	if !ok {
+		return Table{}, ErrTableDoesNotExist
+	}
+
+	return table, nil
+}
+
+//Names returns the sorted names of every table in the library.
+func (l *Library) Names() []string {
+	var names []string
+	for name := range l.snapshot() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+//snapshot returns the current tables, the map must not be changed.
+func (l *Library) snapshot() map[string]Table {
+	state := l.state.Load()
+	if state == nil {
+		return nil
+	}
+
+	return state.tables
+}
+
+//index returns the search index of the current tables, it must not be changed.
+func (l *Library) index() *searchIndex {
+	state := l.state.Load()
+	if state == nil {
+		return nil
+	}
+
+	return state.index
+}
+
+//update makes a change to the table with the provided name in a copy of the current tables, and then replaces the
+//current tables with the copy, along with a search index updated for the change.
+func (l *Library) update(name string, change func(tables map[string]Table)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	next := copyTables(l.snapshot())
+	change(next)
+	index := l.index().copy()
+	index.update(next, name)
+	l.state.Store(&libraryState{tables: next, index: index})
+}
+
+func copyTables(tables map[string]Table) map[string]Table {
+	copied := make(map[string]Table, len(tables)+1)
+	for name, table := range tables {
+		copied[name] = table
+	}
+
+	return copied
+}
+
+//Expression runs the table expression (e.g. 2?tablename, 4#tablename, uni:3?tablename) against the table it names.
+func (l *Library) Expression(te string) ([][]string, error) {
+	return l.ExpressionWith(nil, te)
+}
+
+//ExpressionWith runs the table expression the same as Expression, using r for every roll. A nil r uses the dice package.
+func (l *Library) ExpressionWith(r Rand, te string) ([][]string, error) {
+	name := ParseTablename(strings.TrimSpace(te))
+	if name == "" {
+		return nil, ErrInvalidTableExpression
+	}
+
+	table, err := l.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.ExpressionWith(r, strings.TrimSpace(te))
+}
+
+//ExpressionIn runs the table expression the same as ExpressionWith, translated to the first of locales that has a translation (see Localize).
+func (l *Library) ExpressionIn(r Rand, te string, locales ...string) ([][]string, error) {
+	name := ParseTablename(strings.TrimSpace(te))
+	if name == "" {
+		return nil, ErrInvalidTableExpression
+	}
+
+	table, err := l.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.ExpressionIn(r, strings.TrimSpace(te), locales...)
+}
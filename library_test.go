@@ -0,0 +1,234 @@
+package tables
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestLibrary(t *testing.T) {
+	encounters, _ := Load(testCSV, "encounters", "Encounters", "d6")
+	abilities, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+	library := NewLibrary(encounters, abilities)
+
+	t.Run("validate table names are returned sorted", func(t *testing.T) {
+		want := []string{"abilities", "encounters"}
+		got := library.Names()
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate a table is returned by name", func(t *testing.T) {
+		got, err := library.Table("encounters")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if !reflect.DeepEqual(encounters, got) {
+			t.Errorf("want %v, got %v", encounters, got)
+		}
+	})
+
+	t.Run("validate an error is returned for a table that is not in the library", func(t *testing.T) {
+		_, err := library.Table("nope")
+		if err != ErrTableDoesNotExist {
+			t.Errorf("want %s, got %v", ErrTableDoesNotExist, err)
+		}
+	})
+
+	t.Run("validate table expressions are run against the named table", func(t *testing.T) {
+		got, err := library.Expression("3#encounters")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := [][]string{testCSV[0], testCSV[3]}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate unique table expressions are run against the named table", func(t *testing.T) {
+		got, err := library.Expression("uni:6?encounters")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if len(got) != 7 {
+			t.Errorf("want 7, got %d", len(got))
+		}
+	})
+
+	testErrors := []struct {
+		name       string
+		expression string
+		want       error
+	}{
+		{name: "validate an error is returned for an invalid table expression", expression: "encounters", want: ErrInvalidTableExpression},
+		{name: "validate an error is returned for a table that is not in the library", expression: "2?nope", want: ErrTableDoesNotExist},
+		{name: "validate an error is returned for a table that is not rollable", expression: "2?abilities", want: ErrTableNotRollable},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := library.Expression(test.expression)
+
+			if err != test.want {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+
+	t.Run("validate tables can be replaced and removed", func(t *testing.T) {
+		replaced := encounters
+		replaced.Meta.DisplayName = "Replaced"
+		library.Add(replaced)
+		got, _ := library.Table("encounters")
+		if got.Meta.DisplayName != "Replaced" {
+			t.Errorf("want Replaced, got %s", got.Meta.DisplayName)
+		}
+
+		library.Remove("encounters")
+		_, err := library.Table("encounters")
+		if err != ErrTableDoesNotExist {
+			t.Errorf("want %s, got %v", ErrTableDoesNotExist, err)
+		}
+	})
+}
+
+func TestLibrary_changes(t *testing.T) {
+	encounters, _ := Load(testCSV, "encounters", "Encounters", "d6")
+	abilities, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+
+	t.Run("validate added tables are copied", func(t *testing.T) {
+		table, _ := Load([][]string{{"D1", "Result"}, {"1", "Quiet"}}, "quiet", "Quiet", "d1")
+		library := NewLibrary(table)
+		table.Rows[0].Results[1] = "Changed"
+
+		got, _ := library.Table("quiet")
+		if got.Rows[0].Results[1] != "Quiet" {
+			t.Errorf("want Quiet, got %s", got.Rows[0].Results[1])
+		}
+	})
+
+	t.Run("validate every table is replaced", func(t *testing.T) {
+		library := NewLibrary(encounters)
+		library.Replace(abilities)
+
+		want := []string{"abilities"}
+		if got := library.Names(); !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate a table is updated", func(t *testing.T) {
+		library := NewLibrary(encounters)
+		err := library.Update("encounters", func(table Table) (Table, error) {
+			return table, table.UpdateCell(1, 1, "Quiet")
+		})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		got, _ := library.Table("encounters")
+		if got.Rows[1].Results[1] != "Quiet" {
+			t.Errorf("want Quiet, got %s", got.Rows[1].Results[1])
+		}
+		if encounters.Rows[1].Results[1] != "No encounter" {
+			t.Errorf("want No encounter, got %s", encounters.Rows[1].Results[1])
+		}
+	})
+
+	testErrors := []struct {
+		name string
+		f    func(table Table) (Table, error)
+		want error
+	}{
+		{name: "validate an error from the update is returned", f: func(table Table) (Table, error) { return table, table.RemoveRow(10) }, want: ErrRowDoesNotExist},
+		{name: "validate an error is returned for renaming a table", f: func(table Table) (Table, error) { table.Meta.Name = "renamed"; return table, nil }, want: ErrTableInvalid},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			library := NewLibrary(encounters)
+			err := library.Update("encounters", test.f)
+			if err != test.want {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+
+			got, _ := library.Table("encounters")
+			if !reflect.DeepEqual(encounters, got) {
+				t.Errorf("want %v, got %v", encounters, got)
+			}
+		})
+	}
+
+	t.Run("validate an error is returned for updating a table that is not in the library", func(t *testing.T) {
+		err := NewLibrary().Update("nope", func(table Table) (Table, error) { return table, nil })
+		if err != ErrTableDoesNotExist {
+			t.Errorf("want %v, got %v", ErrTableDoesNotExist, err)
+		}
+	})
+
+	t.Run("validate snapshots do not see later changes", func(t *testing.T) {
+		library := NewLibrary(encounters)
+		snapshot := library.Snapshot()
+		library.Remove("encounters")
+		library.Add(abilities)
+
+		want := []string{"encounters"}
+		if got := snapshot.Names(); !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate a zero library is empty", func(t *testing.T) {
+		var library Library
+		if names := library.Names(); len(names) != 0 {
+			t.Errorf("want no names, got %v", names)
+		}
+		library.Add(abilities)
+		if _, err := library.Table("abilities"); err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+	})
+}
+
+//TestLibrary_concurrent is meant to be run with -race, readers roll tables while writers add, update, and remove them.
+func TestLibrary_concurrent(t *testing.T) {
+	encounters, _ := Load(testCSV, "encounters", "Encounters", "d6")
+	library := NewLibrary(encounters)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				rows, err := library.ExpressionWith(NewRand(int64(j)), "2?encounters")
+				if err != nil || len(rows) != 3 {
+					t.Errorf("want 3 rows, got %v (%v)", rows, err)
+					return
+				}
+				library.Names()
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				name := fmt.Sprintf("table_%d", i)
+				library.Add(Table{Meta: Meta{Name: name}})
+				library.Update("encounters", func(table Table) (Table, error) {
+					return table, table.UpdateCell(1, 1, name)
+				})
+				library.Remove(name)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := library.Names(); !reflect.DeepEqual([]string{"encounters"}, got) {
+		t.Errorf("want [encounters], got %v", got)
+	}
+}
@@ -0,0 +1,139 @@
+package tables
+
+import "strings"
+
+const ErrInvalidLocale = TableError("locale does not have a record for every row of the table")
+
+//MetaLocale stores the translated metadata of a table for a single locale, empty fields are not translated.
+type MetaLocale struct {
+	DisplayName string   `json:"display_name,omitempty" yaml:"display_name,omitempty"`
+	Title       string   `json:"title,omitempty" yaml:"title,omitempty"`
+	FlavorText  string   `json:"flavor_text,omitempty" yaml:"flavor_text,omitempty"`
+	Headers     []string `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
+//AddLocale adds a translation of the table for locale (e.g. es, de-AT). The records are laid out the same as the
+//table's Records, a header followed by a record for each row in the same order, and replace any previous translation
+//for locale. The roll column of a rollable table is never translated, the table's own rolls are always used.
+func (t *Table) AddLocale(locale string, records [][]string, displayName string) error {
+	if locale == "" || len(records) != len(t.Rows)+1 {
+		return ErrInvalidLocale
+	}
+	for _, record := range records {
+		if len(record) != t.Meta.ColumnCount {
+			return ErrInvalidColumnCount
+		}
+	}
+
+	locales := make(map[string]MetaLocale, len(t.Meta.Locales)+1)
+	for key, value := range t.Meta.Locales {
+		locales[key] = value
+	}
+	meta := locales[locale]
+	meta.DisplayName = displayName
+	meta.Headers = append([]string(nil), records[0]...)
+	locales[locale] = meta
+	t.Meta.Locales = locales
+
+	rows := make([]Row, len(t.Rows))
+	for i, row := range t.Rows {
+		results := make(map[string][]string, len(row.Locales)+1)
+		for key, value := range row.Locales {
+			results[key] = value
+		}
+		results[locale] = append([]string(nil), records[i+1]...)
+		row.Locales = results
+		rows[i] = row
+	}
+	t.Rows = rows
+
+	return nil
+}
+
+//Localize returns the table translated to the first of locales that has a translation, falling back to the table's
+//own values for anything that is not translated. Each locale also falls back to its parent language (e.g. es-MX
+//falls back to es) before the next locale is tried. The returned table does not have any locales.
+func (t Table) Localize(locales ...string) Table {
+	chain := localeChain(locales)
+
+	meta := t.Meta
+	meta.Locales = nil
+	for i := len(chain) - 1; i >= 0; i-- {
+		translation, ok := t.Meta.Locales[chain[i]]
+		if !ok {
+			continue
+		}
+		meta.DisplayName = firstNonEmpty(translation.DisplayName, meta.DisplayName)
+		meta.Title = firstNonEmpty(translation.Title, meta.Title)
+		meta.FlavorText = firstNonEmpty(translation.FlavorText, meta.FlavorText)
+		if len(translation.Headers) == len(meta.Headers) {
+			meta.Headers = translation.Headers
+		}
+	}
+
+	rows := make([]Row, len(t.Rows))
+	for i, row := range t.Rows {
+		results := row.Results
+		for _, locale := range chain {
+			translation, ok := row.Locales[locale]
+			if ok && len(translation) == len(row.Results) {
+				results = append([]string(nil), translation...)
+				if t.Meta.RollableTable && len(results) > 0 {
+					results[0] = row.Results[0]
+				}
+				break
+			}
+		}
+
+		row.Results = results
+		row.Locales = nil
+		row.HasRollExpression = false
+		for _, result := range results {
+			row.HasRollExpression = row.HasRollExpression || RollableString(result)
+		}
+		rows[i] = row
+	}
+
+	return Table{Meta: meta, Rows: rows}
+}
+
+//GetRowIn returns the row for roll the same as GetRowWith, translated to the first of locales that has a translation (see Localize).
+func (t Table) GetRowIn(r Rand, roll int, locales ...string) ([]string, error) {
+	return t.Localize(locales...).GetRowWith(r, roll)
+}
+
+//ExpressionIn runs the table expression the same as ExpressionWith, translated to the first of locales that has a translation (see Localize).
+func (t Table) ExpressionIn(r Rand, te string, locales ...string) ([][]string, error) {
+	return t.Localize(locales...).ExpressionWith(r, te)
+}
+
+//localeChain returns locales with each followed by its parent languages (e.g. es-MX, es), without duplicates.
+func localeChain(locales []string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+	for _, locale := range locales {
+		for locale != "" {
+			if !seen[locale] {
+				seen[locale] = true
+				chain = append(chain, locale)
+			}
+			end := strings.LastIndexAny(locale, "-_")
+			if end == -1 {
+				break
+			}
+			locale = locale[:end]
+		}
+	}
+
+	return chain
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,187 @@
+package tables
+
+import (
+	"reflect"
+	"testing"
+)
+
+var weatherCSV = [][]string{
+	{"D3", "Weather", "Effect"},
+	{"1", "Rain", "{{1d1}} hour of mud"},
+	{"2", "Fog", "Hard to see"},
+	{"3", "Sun", "Nothing"},
+}
+
+var weatherES = [][]string{
+	{"D3", "Clima", "Efecto"},
+	{"uno", "Lluvia", "{{1d1}} hora de barro"},
+	{"dos", "Niebla", "Difícil de ver"},
+	{"tres", "Sol", "Nada"},
+}
+
+func testLocaleTable(t *testing.T) Table {
+	table, err := Load(weatherCSV, "weather", "Weather", "d3")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	err = table.AddLocale("es", weatherES, "Clima")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	return table
+}
+
+func TestTable_AddLocale(t *testing.T) {
+	t.Run("validate a locale is added to the meta data and every row", func(t *testing.T) {
+		table := testLocaleTable(t)
+
+		want := map[string]MetaLocale{"es": {DisplayName: "Clima", Headers: weatherES[0]}}
+		if !reflect.DeepEqual(want, table.Meta.Locales) {
+			t.Errorf("want %v, got %v", want, table.Meta.Locales)
+		}
+		for i, row := range table.Rows {
+			if !reflect.DeepEqual(weatherES[i+1], row.Locales["es"]) {
+				t.Errorf("want %v, got %v", weatherES[i+1], row.Locales["es"])
+			}
+		}
+
+		err := table.Validate()
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+	})
+
+	t.Run("validate adding a locale does not change copies of the table", func(t *testing.T) {
+		table := testLocaleTable(t)
+		original := table
+		table.AddLocale("de", weatherES, "Wetter")
+
+		if _, ok := original.Meta.Locales["de"]; ok {
+			t.Errorf("want no de locale in the original meta data")
+		}
+		if _, ok := original.Rows[0].Locales["de"]; ok {
+			t.Errorf("want no de locale in the original rows")
+		}
+	})
+
+	t.Run("validate locales are kept when packed and unpacked", func(t *testing.T) {
+		table := testLocaleTable(t)
+		_, data := table.Pack()
+
+		got := Table{}
+		got.Unpack(data)
+		if !reflect.DeepEqual(table, got) {
+			t.Errorf("want %v, got %v", table, got)
+		}
+	})
+
+	t.Run("validate locales are kept in sync by edits", func(t *testing.T) {
+		table := testLocaleTable(t)
+		err := table.AddColumn("Note", "-")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		err = table.UpdateCell(1, 1, "Mist")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		err = table.Validate()
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		want := []string{"dos", "Niebla", "Difícil de ver", "-"}
+		if !reflect.DeepEqual(want, table.Rows[1].Locales["es"]) {
+			t.Errorf("want %v, got %v", want, table.Rows[1].Locales["es"])
+		}
+	})
+
+	testErrors := []struct {
+		name    string
+		locale  string
+		records [][]string
+		want    error
+	}{
+		{name: "validate an error is returned for an empty locale", locale: "", records: weatherES, want: ErrInvalidLocale},
+		{name: "validate an error is returned for missing rows", locale: "es", records: weatherES[:3], want: ErrInvalidLocale},
+		{name: "validate an error is returned for missing columns", locale: "es", records: [][]string{{"D3"}, {"1"}, {"2"}, {"3"}}, want: ErrInvalidColumnCount},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			table, _ := Load(weatherCSV, "weather", "Weather", "d3")
+			err := table.AddLocale(test.locale, test.records, "Clima")
+			if err != test.want {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestTable_Localize(t *testing.T) {
+	table := testLocaleTable(t)
+	table.Meta.FlavorText = "The sky today."
+	table.Meta.Locales["es-MX"] = MetaLocale{FlavorText: "El cielo de hoy."}
+
+	tests := []struct {
+		name        string
+		locales     []string
+		displayName string
+		flavorText  string
+		headers     []string
+		row         []string
+	}{
+		{name: "validate the table's own values are used without a locale", displayName: "Weather", flavorText: "The sky today.", headers: weatherCSV[0], row: weatherCSV[2]},
+		{name: "validate a locale is used", locales: []string{"es"}, displayName: "Clima", flavorText: "The sky today.", headers: weatherES[0], row: []string{"2", "Niebla", "Difícil de ver"}},
+		{name: "validate a locale falls back to its language", locales: []string{"es-MX"}, displayName: "Clima", flavorText: "El cielo de hoy.", headers: weatherES[0], row: []string{"2", "Niebla", "Difícil de ver"}},
+		{name: "validate locales fall back in order", locales: []string{"fr", "es"}, displayName: "Clima", flavorText: "The sky today.", headers: weatherES[0], row: []string{"2", "Niebla", "Difícil de ver"}},
+		{name: "validate an unknown locale uses the table's own values", locales: []string{"de"}, displayName: "Weather", flavorText: "The sky today.", headers: weatherCSV[0], row: weatherCSV[2]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := table.Localize(test.locales...)
+
+			if got.Meta.DisplayName != test.displayName {
+				t.Errorf("want %s, got %s", test.displayName, got.Meta.DisplayName)
+			}
+			if got.Meta.FlavorText != test.flavorText {
+				t.Errorf("want %s, got %s", test.flavorText, got.Meta.FlavorText)
+			}
+			if !reflect.DeepEqual(test.headers, got.Meta.Headers) {
+				t.Errorf("want %v, got %v", test.headers, got.Meta.Headers)
+			}
+			if !reflect.DeepEqual(test.row, got.Rows[1].Results) {
+				t.Errorf("want %v, got %v", test.row, got.Rows[1].Results)
+			}
+			if got.Meta.Locales != nil || got.Rows[1].Locales != nil {
+				t.Errorf("want no locales in a localized table")
+			}
+		})
+	}
+
+	t.Run("validate rows are rolled in a locale", func(t *testing.T) {
+		got, err := table.GetRowIn(nil, 1, "es")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := []string{"1", "Lluvia", "1 hora de barro"}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate table expressions are run in a locale", func(t *testing.T) {
+		got, err := NewLibrary(table).ExpressionIn(nil, "3#weather", "es-ES")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := [][]string{weatherES[0], {"3", "Sol", "Nada"}}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package tables
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//Conflict is a change made differently by both sides of a three-way merge.
+//Field is set for meta data conflicts (using the field's JSON name), Key is set for row conflicts (see RowKey).
+//Base, Ours, and Theirs hold the JSON encoded values from each table, null if a row does not exist in that table.
+type Conflict struct {
+	Field  string          `json:"field,omitempty"`
+	Key    string          `json:"key,omitempty"`
+	Base   json.RawMessage `json:"base"`
+	Ours   json.RawMessage `json:"ours"`
+	Theirs json.RawMessage `json:"theirs"`
+}
+
+//ThreeWayMerge merges the changes made in ours and theirs, both edited from base.
+//Changes to different meta fields or rows are combined, a row that was changed on both sides, or rows added on
+//both sides with overlapping rolls, are reported as conflicts. The merged table keeps our version of any conflict.
+func ThreeWayMerge(base, ours, theirs Table) (Table, []Conflict) {
+	var conflicts []Conflict
+	merged := Table{}
+
+	baseMeta := reflect.ValueOf(base.Meta)
+	oursMeta := reflect.ValueOf(ours.Meta)
+	theirsMeta := reflect.ValueOf(theirs.Meta)
+	mergedMeta := reflect.ValueOf(&merged.Meta).Elem()
+	for i := 0; i < mergedMeta.NumField(); i++ {
+		b := baseMeta.Field(i).Interface()
+		o := oursMeta.Field(i).Interface()
+		th := theirsMeta.Field(i).Interface()
+
+		value, ok := mergeValue(b, o, th)
+		if !ok {
+			conflicts = append(conflicts, Conflict{Field: metaFieldName(mergedMeta.Type().Field(i)), Base: toJSON(b), Ours: toJSON(o), Theirs: toJSON(th)})
+		}
+		mergedMeta.Field(i).Set(reflect.ValueOf(value))
+	}
+
+	baseRows := rowsByKey(base.Rows)
+	oursRows := rowsByKey(ours.Rows)
+	theirsRows := rowsByKey(theirs.Rows)
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, rows := range [][]Row{base.Rows, ours.Rows, theirs.Rows} {
+		for _, row := range rows {
+			key := RowKey(row)
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	//theirs tracks which merged rows were taken from their table, so overlapping rolls can be blamed on them
+	theirsKeys := make(map[string]bool)
+	for _, key := range keys {
+		b, inBase := baseRows[key]
+		o, inOurs := oursRows[key]
+		th, inTheirs := theirsRows[key]
+
+		value, ok := mergeValue(optionalRow(b, inBase), optionalRow(o, inOurs), optionalRow(th, inTheirs))
+		if !ok {
+			conflicts = append(conflicts, Conflict{Key: key, Base: toJSON(optionalRow(b, inBase)), Ours: toJSON(optionalRow(o, inOurs)), Theirs: toJSON(optionalRow(th, inTheirs))})
+		}
+
+		row := value.(*Row)
+		if row == nil {
+			continue
+		}
+		if ok && !reflect.DeepEqual(optionalRow(o, inOurs), value) {
+			theirsKeys[key] = true
+		}
+		merged.Rows = append(merged.Rows, *row)
+	}
+	sortRows(merged.Rows)
+
+	//rows added or changed independently may still claim the same rolls, keep ours and report the conflict
+	var rows []Row
+	for _, row := range merged.Rows {
+		if theirsKeys[RowKey(row)] {
+			if overlapping, ok := overlappingRow(row, merged.Rows, theirsKeys); ok {
+				b, inBase := baseRows[RowKey(row)]
+				conflicts = append(conflicts, Conflict{Key: RowKey(row), Base: toJSON(optionalRow(b, inBase)), Ours: toJSON(&overlapping), Theirs: toJSON(&row)})
+				continue
+			}
+		}
+		rows = append(rows, row)
+	}
+	merged.Rows = rows
+
+	return merged, conflicts
+}
+
+//mergeValue merges a single value, returning ours and false if both sides changed it differently.
+func mergeValue(base, ours, theirs interface{}) (interface{}, bool) {
+	switch {
+	case reflect.DeepEqual(ours, theirs):
+		return ours, true
+	case reflect.DeepEqual(base, ours):
+		return theirs, true
+	case reflect.DeepEqual(base, theirs):
+		return ours, true
+	}
+
+	return ours, false
+}
+
+//overlappingRow returns a row, not taken from their table, that shares a roll value with row.
+func overlappingRow(row Row, rows []Row, theirsKeys map[string]bool) (Row, bool) {
+	start, end := rowBounds(row)
+	for _, other := range rows {
+		if theirsKeys[RowKey(other)] {
+			continue
+		}
+		otherStart, otherEnd := rowBounds(other)
+		if start <= otherEnd && otherStart <= end {
+			return other, true
+		}
+	}
+
+	return Row{}, false
+}
+
+//rowBounds returns the first and last roll value of a row.
+func rowBounds(row Row) (int, int) {
+	if !RangedRoll(row.RollRange) {
+		return row.DieRoll, row.DieRoll
+	}
+
+	parts := strings.Split(row.RollRange, "-")
+	start, _ := strconv.Atoi(parts[0])
+	end, _ := strconv.Atoi(parts[1])
+
+	return start, end
+}
+
+func rowsByKey(rows []Row) map[string]Row {
+	keyed := make(map[string]Row)
+	for _, row := range rows {
+		keyed[RowKey(row)] = row
+	}
+
+	return keyed
+}
+
+func optionalRow(row Row, ok bool) *Row {
+	if !ok {
+		return nil
+	}
+
+	return &row
+}
+
+func toJSON(value interface{}) json.RawMessage {
+	b, _ := json.Marshal(value)
+
+	return b
+}
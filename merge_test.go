@@ -0,0 +1,110 @@
+package tables
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var mergeBaseCSV = [][]string{
+	{"D6", "Result"},
+	{"1-2", "Goblins"},
+	{"3-4", "Wolves"},
+	{"5-6", "Nothing"},
+}
+
+func Test_ThreeWayMerge(t *testing.T) {
+	t.Run("validate changes to different rows and meta fields are merged", func(t *testing.T) {
+		base, _ := Load(mergeBaseCSV, "encounters", "Encounters", "d6")
+		ours, _ := Load(mergeBaseCSV, "encounters", "Encounters", "d6")
+		theirs, _ := Load(mergeBaseCSV, "encounters", "Encounters", "d6")
+		ours.Rows[0].Results = []string{"1-2", "Hobgoblins"}
+		ours.Meta.Title = "Forest Encounters"
+		theirs.Rows[2].Results = []string{"5-6", "A lost merchant"}
+		theirs.Meta.FlavorText = "The trees whisper."
+
+		got, conflicts := ThreeWayMerge(base, ours, theirs)
+		if len(conflicts) != 0 {
+			t.Errorf("want 0 conflicts, got %v", conflicts)
+		}
+
+		want, _ := Load([][]string{
+			{"D6", "Result"},
+			{"1-2", "Hobgoblins"},
+			{"3-4", "Wolves"},
+			{"5-6", "A lost merchant"},
+		}, "encounters", "Encounters", "d6")
+		want.Meta.Title = "Forest Encounters"
+		want.Meta.FlavorText = "The trees whisper."
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate rows removed and added on different sides are merged", func(t *testing.T) {
+		base, _ := Load(mergeBaseCSV, "encounters", "Encounters", "d6")
+		ours, _ := Load(mergeBaseCSV, "encounters", "Encounters", "d6")
+		theirs, _ := Load([][]string{
+			{"D6", "Result"},
+			{"1-2", "Goblins"},
+			{"3-4", "Wolves"},
+			{"5", "Nothing"},
+			{"6", "A dragon"},
+		}, "encounters", "Encounters", "d6")
+
+		got, conflicts := ThreeWayMerge(base, ours, theirs)
+		if len(conflicts) != 0 {
+			t.Errorf("want 0 conflicts, got %v", conflicts)
+		}
+		if !reflect.DeepEqual(theirs, got) {
+			t.Errorf("want %v, got %v", theirs, got)
+		}
+	})
+
+	t.Run("validate a row changed on both sides is a conflict", func(t *testing.T) {
+		base, _ := Load(mergeBaseCSV, "encounters", "Encounters", "d6")
+		ours, _ := Load(mergeBaseCSV, "encounters", "Encounters", "d6")
+		theirs, _ := Load(mergeBaseCSV, "encounters", "Encounters", "d6")
+		ours.Rows[1].Results = []string{"3-4", "Dire wolves"}
+		theirs.Rows[1].Results = []string{"3-4", "Bears"}
+
+		got, conflicts := ThreeWayMerge(base, ours, theirs)
+		want := []Conflict{{Key: "3-4", Base: toJSON(&base.Rows[1]), Ours: toJSON(&ours.Rows[1]), Theirs: toJSON(&theirs.Rows[1])}}
+		if !reflect.DeepEqual(want, conflicts) {
+			t.Errorf("want %s, got %s", want, conflicts)
+		}
+		if !reflect.DeepEqual(ours.Rows[1], got.Rows[1]) {
+			t.Errorf("want %v, got %v", ours.Rows[1], got.Rows[1])
+		}
+	})
+
+	t.Run("validate a meta field changed on both sides is a conflict", func(t *testing.T) {
+		base, _ := Load(mergeBaseCSV, "encounters", "Encounters", "d6")
+		ours, _ := Load(mergeBaseCSV, "encounters", "Forest Encounters", "d6")
+		theirs, _ := Load(mergeBaseCSV, "encounters", "Swamp Encounters", "d6")
+
+		got, conflicts := ThreeWayMerge(base, ours, theirs)
+		want := []Conflict{{Field: "display_name", Base: json.RawMessage(`"Encounters"`), Ours: json.RawMessage(`"Forest Encounters"`), Theirs: json.RawMessage(`"Swamp Encounters"`)}}
+		if !reflect.DeepEqual(want, conflicts) {
+			t.Errorf("want %s, got %s", want, conflicts)
+		}
+		if got.Meta.DisplayName != "Forest Encounters" {
+			t.Errorf("want Forest Encounters, got %s", got.Meta.DisplayName)
+		}
+	})
+
+	t.Run("validate rows added on both sides with overlapping rolls are a conflict", func(t *testing.T) {
+		base, _ := Load(mergeBaseCSV[:3], "encounters", "Encounters", "d6")
+		ours, _ := Load(append(mergeBaseCSV[:3:3], []string{"5", "Bandits"}), "encounters", "Encounters", "d6")
+		theirs, _ := Load(append(mergeBaseCSV[:3:3], []string{"5-6", "Trolls"}), "encounters", "Encounters", "d6")
+
+		got, conflicts := ThreeWayMerge(base, ours, theirs)
+		want := []Conflict{{Key: "5-6", Base: json.RawMessage(`null`), Ours: toJSON(&ours.Rows[2]), Theirs: toJSON(&theirs.Rows[2])}}
+		if !reflect.DeepEqual(want, conflicts) {
+			t.Errorf("want %s, got %s", want, conflicts)
+		}
+		if !reflect.DeepEqual(ours, got) {
+			t.Errorf("want %v, got %v", ours, got)
+		}
+	})
+}
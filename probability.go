@@ -0,0 +1,320 @@
+package tables
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/fantastical-world/dice"
+)
+
+const ErrUnsupportedRollExpression = TableError("probabilities are not supported for this roll expression")
+
+//maxDistributionValues is the most values (the number of dice times their sides) a term of a roll expression can have
+//for RollDistribution, the probabilities of larger terms take too long to work out.
+const maxDistributionValues = 2000
+
+//RollDistribution returns the exact probability of each value that can be rolled with the provided roll expression.
+//The prefixes accepted by dice.RollExpression (max:, min:, half:, dub:, dropL:, and dropH:) are supported,
+//but dropping both the lowest and highest die is not. Like dice.RollExpression, half: and dub: are ignored with max: and min:.
+//ErrUnsupportedRollExpression is returned for terms with more than 2000 values (e.g. 100d100).
+func RollDistribution(rollExpression string) (map[int]float64, error) {
+	prefixes, expression := parseRollPrefixes(rollExpression)
+
+	match := dice.RollExpressionRE.FindStringSubmatch(expression)
+	if match == nil {
+		return nil, ErrInvalidRollExpression
+	}
+
+	hasSecondExpression := match[5] != ""
+	if hasSecondExpression && (prefixes.wantsMax || prefixes.wantsMin) {
+		return nil, ErrInvalidRollExpression
+	}
+
+	number, sides, ok := termDice(match[1], match[2])
+	if !ok {
+		return nil, ErrInvalidRollExpression
+	}
+	if number*sides > maxDistributionValues {
+		return nil, ErrUnsupportedRollExpression
+	}
+
+	var distribution map[int]float64
+	switch {
+	case prefixes.wantsMax:
+		distribution = extremeDie(number, sides, true)
+	case prefixes.wantsMin:
+		distribution = extremeDie(number, sides, false)
+	case prefixes.dropLowest && prefixes.dropHighest:
+		return nil, ErrUnsupportedRollExpression
+	case prefixes.dropLowest:
+		distribution = dropDie(number, sides, true)
+	case prefixes.dropHighest:
+		distribution = dropDie(number, sides, false)
+	default:
+		distribution = sumDice(number, sides)
+	}
+	distribution = shift(distribution, termModifier(match[3], match[4]))
+
+	//dice.RollExpression returns the highest or lowest die before halving or doubling
+	if prefixes.wantsMax || prefixes.wantsMin {
+		return distribution, nil
+	}
+
+	if hasSecondExpression {
+		secondNumber, secondSides, ok := termDice(match[7], match[8])
+		if !ok {
+			return nil, ErrInvalidRollExpression
+		}
+		if secondNumber*secondSides > maxDistributionValues {
+			return nil, ErrUnsupportedRollExpression
+		}
+
+		second := shift(sumDice(secondNumber, secondSides), termModifier(match[9], match[10]))
+		if match[6] == "-" {
+			second = scale(second, -1)
+		}
+		distribution = convolve(distribution, second)
+	}
+
+	if prefixes.halfResult {
+		return transform(distribution, func(value int) int { return value / 2 }), nil
+	}
+
+	if prefixes.doubleResult {
+		return scale(distribution, 2), nil
+	}
+
+	return distribution, nil
+}
+
+//Probabilities returns the probability of each row being rolled, in the same order as the table's rows.
+//Rows of tables that are not rollable are all equally likely, matching RandomRow.
+func (t Table) Probabilities() ([]float64, error) {
+	probabilities := make([]float64, len(t.Rows))
+	if !t.Meta.RollableTable {
+		for i := range probabilities {
+			probabilities[i] = 1 / float64(len(t.Rows))
+		}
+		return probabilities, nil
+	}
+
+	distribution, err := RollDistribution(t.Meta.RollExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	for value, probability := range distribution {
+		if index := t.rowIndex(value); index >= 0 {
+			probabilities[index] += probability
+		}
+	}
+
+	return probabilities, nil
+}
+
+//Rescale changes the roll expression of a rollable table, giving each row a range of the new roll expression so that
+//its probability is as close as possible to its current probability. The rows keep their order, and the roll column
+//is rewritten as it is by Renumber. The approximation error is returned as the total variation distance between the
+//old and new row probabilities, 0 means every row is exactly as likely as it was.
+func (t *Table) Rescale(rollExpression string) (float64, error) {
+	if !t.Meta.RollableTable {
+		return 0, ErrTableNotRollable
+	}
+
+	rows := append([]Row(nil), t.Rows...)
+	sortRows(rows)
+	current := Table{Meta: t.Meta, Rows: rows}
+	want, err := current.Probabilities()
+	if err != nil {
+		return 0, err
+	}
+
+	//rolls that don't match a row are ignored, only the relative probabilities of the rows matter
+	total := 0.0
+	for _, probability := range want {
+		total += probability
+	}
+	for i := range want {
+		if total == 0 {
+			want[i] = 1 / float64(len(want))
+			continue
+		}
+		want[i] /= total
+	}
+
+	rows, distance, err := assignRolls(rows, want, rollExpression)
+	if err != nil {
+		return 0, err
+	}
+	t.Rows = rows
+	t.setRollExpression(rollExpression)
+
+	return distance, nil
+}
+
+//assignRolls returns rows with each given a range of the roll expression's values, in order, so that the probability
+//of each row is as close as possible to want. The total variation distance between want and the assigned
+//probabilities is also returned.
+func assignRolls(rows []Row, want []float64, rollExpression string) ([]Row, float64, error) {
+	distribution, err := RollDistribution(rollExpression)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var values []int
+	for value := range distribution {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+
+	if len(values) < len(rows) {
+		return nil, 0, ErrNotEnoughRollValues
+	}
+
+	cumulative := make([]float64, len(values)+1)
+	for i, value := range values {
+		cumulative[i+1] = cumulative[i] + distribution[value]
+	}
+
+	//cost[r][v] is the smallest error of giving the first r rows the first v values, where every row gets at least one value
+	cost := make([][]float64, len(rows)+1)
+	cut := make([][]int, len(rows)+1)
+	for r := range cost {
+		cost[r] = make([]float64, len(values)+1)
+		cut[r] = make([]int, len(values)+1)
+		for v := range cost[r] {
+			cost[r][v] = math.Inf(1)
+		}
+	}
+	cost[0][0] = 0
+	for r := 1; r <= len(rows); r++ {
+		for v := r; v <= len(values)-(len(rows)-r); v++ {
+			for previous := r - 1; previous < v; previous++ {
+				c := cost[r-1][previous] + math.Abs(cumulative[v]-cumulative[previous]-want[r-1])
+				if c < cost[r][v] {
+					cost[r][v] = c
+					cut[r][v] = previous
+				}
+			}
+		}
+	}
+
+	assigned := make([]Row, len(rows))
+	end := len(values)
+	for r := len(rows); r > 0; r-- {
+		start := cut[r][end]
+		assigned[r-1] = withRoll(rows[r-1], values[start], values[end-1])
+		end = start
+	}
+
+	return assigned, cost[len(rows)][len(values)] / 2, nil
+}
+
+//termDice returns the number of dice and sides of a single term of a roll expression, numbers that don't fit in 32
+//bits are not valid so they can be multiplied without overflowing.
+func termDice(number, sides string) (int, int, bool) {
+	n := 1
+	if number != "" {
+		n, _ = strconv.Atoi(number)
+	}
+	s, _ := strconv.Atoi(sides)
+
+	return n, s, n > 0 && s > 0 && n <= math.MaxInt32 && s <= math.MaxInt32
+}
+
+//termModifier returns the signed modifier of a single term of a roll expression.
+func termModifier(operator, modifier string) int {
+	m, _ := strconv.Atoi(modifier)
+	if operator == "-" {
+		return -m
+	}
+
+	return m
+}
+
+//sumDice returns the distribution of the sum of number dice with the provided sides.
+func sumDice(number, sides int) map[int]float64 {
+	return faces(number, sides, 1, sides)
+}
+
+//faces returns the distribution of the sum of number dice, counting only rolls where every die is between low and high.
+//The probabilities of rolls outside of low and high are left out, so the result only sums to 1 if every face is included.
+func faces(number, sides, low, high int) map[int]float64 {
+	die := make(map[int]float64)
+	for face := low; face <= high; face++ {
+		die[face] = 1 / float64(sides)
+	}
+
+	distribution := map[int]float64{0: 1}
+	for i := 0; i < number; i++ {
+		distribution = convolve(distribution, die)
+	}
+
+	return distribution
+}
+
+//extremeDie returns the distribution of the highest (or lowest) die of number dice with the provided sides.
+func extremeDie(number, sides int, highest bool) map[int]float64 {
+	distribution := make(map[int]float64)
+	for face := 1; face <= sides; face++ {
+		atMost := math.Pow(float64(face)/float64(sides), float64(number))
+		belowIt := math.Pow(float64(face-1)/float64(sides), float64(number))
+		if highest {
+			distribution[face] = atMost - belowIt
+		} else {
+			distribution[sides-face+1] = atMost - belowIt
+		}
+	}
+
+	return distribution
+}
+
+//dropDie returns the distribution of the sum of number dice with the provided sides, after dropping the lowest (or highest) die.
+func dropDie(number, sides int, lowest bool) map[int]float64 {
+	distribution := make(map[int]float64)
+	for face := 1; face <= sides; face++ {
+		//every roll where all dice are at least (or at most) face, less those where none of them are face
+		all, none := faces(number, sides, face, sides), faces(number, sides, face+1, sides)
+		if !lowest {
+			all, none = faces(number, sides, 1, face), faces(number, sides, 1, face-1)
+		}
+
+		for sum, probability := range all {
+			probability -= none[sum]
+			if probability > 1e-15 {
+				distribution[sum-face] += probability
+			}
+		}
+	}
+
+	return distribution
+}
+
+func convolve(a, b map[int]float64) map[int]float64 {
+	distribution := make(map[int]float64)
+	for x, px := range a {
+		for y, py := range b {
+			distribution[x+y] += px * py
+		}
+	}
+
+	return distribution
+}
+
+func shift(distribution map[int]float64, by int) map[int]float64 {
+	return transform(distribution, func(value int) int { return value + by })
+}
+
+func scale(distribution map[int]float64, by int) map[int]float64 {
+	return transform(distribution, func(value int) int { return value * by })
+}
+
+func transform(distribution map[int]float64, f func(int) int) map[int]float64 {
+	transformed := make(map[int]float64)
+	for value, probability := range distribution {
+		transformed[f(value)] += probability
+	}
+
+	return transformed
+}
@@ -0,0 +1,260 @@
+package tables
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func Test_RollDistribution(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		want       map[int]float64
+	}{
+		{
+			name:       "validate distribution of a single die",
+			expression: "d4",
+			want:       map[int]float64{1: 0.25, 2: 0.25, 3: 0.25, 4: 0.25},
+		},
+		{
+			name:       "validate distribution of multiple dice",
+			expression: "2d3",
+			want:       map[int]float64{2: 1.0 / 9, 3: 2.0 / 9, 4: 3.0 / 9, 5: 2.0 / 9, 6: 1.0 / 9},
+		},
+		{
+			name:       "validate distribution with a modifier",
+			expression: "d2-1",
+			want:       map[int]float64{0: 0.5, 1: 0.5},
+		},
+		{
+			name:       "validate distribution of an expression pair",
+			expression: "d2-d2",
+			want:       map[int]float64{-1: 0.25, 0: 0.5, 1: 0.25},
+		},
+		{
+			name:       "validate distribution of the highest die",
+			expression: "max:2d2",
+			want:       map[int]float64{1: 0.25, 2: 0.75},
+		},
+		{
+			name:       "validate distribution of the lowest die",
+			expression: "min:2d2",
+			want:       map[int]float64{1: 0.75, 2: 0.25},
+		},
+		{
+			name:       "validate distribution when dropping the lowest die",
+			expression: "dropL:2d2",
+			want:       map[int]float64{1: 0.25, 2: 0.75},
+		},
+		{
+			name:       "validate distribution when dropping the highest die",
+			expression: "dropH:2d2",
+			want:       map[int]float64{1: 0.75, 2: 0.25},
+		},
+		{
+			name:       "validate distribution when halving the result",
+			expression: "half:d4",
+			want:       map[int]float64{0: 0.25, 1: 0.5, 2: 0.25},
+		},
+		{
+			name:       "validate distribution when doubling the result",
+			expression: "dub:d2",
+			want:       map[int]float64{2: 0.5, 4: 0.5},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := RollDistribution(test.expression)
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+
+			if len(got) != len(test.want) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+			for value, probability := range test.want {
+				if !almostEqual(probability, got[value]) {
+					t.Errorf("want %v, got %v", test.want, got)
+					break
+				}
+			}
+		})
+	}
+
+	t.Run("validate the distribution of 4d6 dropping the lowest die", func(t *testing.T) {
+		got, err := RollDistribution("dropL:4d6")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		total := 0.0
+		for _, probability := range got {
+			total += probability
+		}
+		if !almostEqual(1, total) {
+			t.Errorf("want 1, got %f", total)
+		}
+		//there are 1296 ways to roll 4d6, 1 way to get 3 and 21 ways to get 18
+		if !almostEqual(1.0/1296, got[3]) || !almostEqual(21.0/1296, got[18]) {
+			t.Errorf("want %f and %f, got %f and %f", 1.0/1296, 21.0/1296, got[3], got[18])
+		}
+	})
+
+	testErrors := []struct {
+		name       string
+		expression string
+		want       error
+	}{
+		{name: "validate an error is returned for an invalid expression", expression: "d", want: ErrInvalidRollExpression},
+		{name: "validate an error is returned for max with an expression pair", expression: "max:d6+d4", want: ErrInvalidRollExpression},
+		{name: "validate an error is returned for dropping both dice", expression: "dropL:dropH:3d6", want: ErrUnsupportedRollExpression},
+		{name: "validate an error is returned for too many dice", expression: "1000d1000", want: ErrUnsupportedRollExpression},
+		{name: "validate an error is returned for too many dice in an expression pair", expression: "d6+100d100", want: ErrUnsupportedRollExpression},
+		{name: "validate an error is returned for counts too large to parse", expression: "99999999999999999999d6", want: ErrInvalidRollExpression},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := RollDistribution(test.expression)
+
+			if err != test.want {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestTable_Probabilities(t *testing.T) {
+	testCases := []struct {
+		name  string
+		table func() Table
+		want  []float64
+	}{
+		{
+			name:  "validate probabilities of a ranged table",
+			table: func() Table { table, _ := Load(rangedCSV, "ranged", "Ranged", "d6"); return table },
+			want:  []float64{1.0 / 3, 1.0 / 3, 1.0 / 3},
+		},
+		{
+			name: "validate probabilities of a table rolled with multiple dice",
+			table: func() Table {
+				table, _ := Load([][]string{{"2D3", "Result"}, {"2-3", "Low"}, {"4", "Middle"}, {"5-6", "High"}}, "test", "Test", "2d3")
+				return table
+			},
+			want: []float64{3.0 / 9, 3.0 / 9, 3.0 / 9},
+		},
+		{
+			name:  "validate rows of a table that is not rollable are equally likely",
+			table: func() Table { table, _ := Load(nonRollableCSV, "abilities", "Abilities", ""); return table },
+			want:  []float64{1.0 / 3, 1.0 / 3, 1.0 / 3},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.table().Probabilities()
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+
+			if len(got) != len(test.want) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+			for i := range test.want {
+				if !almostEqual(test.want[i], got[i]) {
+					t.Errorf("want %v, got %v", test.want, got)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestTable_Rescale(t *testing.T) {
+	t.Run("validate rows are rescaled exactly when possible", func(t *testing.T) {
+		table, _ := Load([][]string{{"D20", "Result"}, {"1-5", "A"}, {"6-10", "B"}, {"11-15", "C"}, {"16-20", "D"}}, "test", "Test", "d20")
+
+		got, err := table.Rescale("d12")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if !almostEqual(0, got) {
+			t.Errorf("want 0, got %f", got)
+		}
+		want := []string{"1-3", "4-6", "7-9", "10-12"}
+		if !reflect.DeepEqual(want, rollColumn(table)) {
+			t.Errorf("want %v, got %v", want, rollColumn(table))
+		}
+		if table.Meta.Headers[0] != "D12" || table.Meta.RollExpression != "d12" {
+			t.Errorf("want D12 and d12, got %s and %s", table.Meta.Headers[0], table.Meta.RollExpression)
+		}
+	})
+
+	t.Run("validate weighted rows keep their probabilities", func(t *testing.T) {
+		table, _ := Load([][]string{{"D10", "Result"}, {"1-7", "Common"}, {"8-9", "Uncommon"}, {"10", "Rare"}}, "test", "Test", "d10")
+
+		got, err := table.Rescale("d100")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if !almostEqual(0, got) {
+			t.Errorf("want 0, got %f", got)
+		}
+		want := []string{"1-70", "71-90", "91-100"}
+		if !reflect.DeepEqual(want, rollColumn(table)) {
+			t.Errorf("want %v, got %v", want, rollColumn(table))
+		}
+	})
+
+	t.Run("validate rows are rescaled to multiple dice as closely as possible", func(t *testing.T) {
+		table, _ := Load(rangedCSV, "ranged", "Ranged", "d6")
+
+		got, err := table.Rescale("2d6")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		//the best split of 2d6 into thirds is 15/36, 11/36, and 10/36 (or the reverse)
+		if !almostEqual(3.0/36, got) {
+			t.Errorf("want %f, got %f", 3.0/36, got)
+		}
+
+		probabilities, _ := table.Probabilities()
+		total := 0.0
+		for _, probability := range probabilities {
+			total += probability
+		}
+		if !almostEqual(1, total) {
+			t.Errorf("want 1, got %f", total)
+		}
+		if err := table.Validate(); err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+	})
+
+	t.Run("validate an error is returned when the die is too small", func(t *testing.T) {
+		table, _ := Load(testCSV, "test", "Test", "d6")
+
+		_, err := table.Rescale("d4")
+		if err != ErrNotEnoughRollValues {
+			t.Errorf("want %s, got %v", ErrNotEnoughRollValues, err)
+		}
+	})
+
+	t.Run("validate an error is returned for a table that is not rollable", func(t *testing.T) {
+		table, _ := Load(nonRollableCSV, "abilities", "Abilities", "")
+
+		_, err := table.Rescale("d6")
+		if err != ErrTableNotRollable {
+			t.Errorf("want %s, got %v", ErrTableNotRollable, err)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package tables
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fantastical-world/dice"
+)
+
+//Rand is a source of random numbers used when rolling tables. It allows rolls to be repeated by seeding the source,
+//the seeded source returned by dice.New (or NewRand) satisfies it. A Rand is not expected to be safe for concurrent use.
+type Rand interface {
+	//RandomRange returns a random number from min to max, inclusive.
+	RandomRange(min, max int) int
+}
+
+//NewRand returns a Rand seeded with seed, rolls made with the same seed will always be the same.
+func NewRand(seed int64) Rand {
+	return dice.New(seed)
+}
+
+//rollWith rolls the roll expression using r, accepting the same expressions and prefixes as dice.RollExpression.
+//A nil r uses dice.RollExpression.
+func rollWith(r Rand, expression string) (int, error) {
+	if r == nil {
+		_, sum, err := dice.RollExpression(expression)
+		return sum, err
+	}
+
+	prefixes, expression := parseRollPrefixes(expression)
+
+	match := dice.RollExpressionRE.FindStringSubmatch(expression)
+	if match == nil {
+		return 0, dice.ErrInvalidRollExpression
+	}
+
+	hasSecondExpression := match[5] != ""
+	if hasSecondExpression && (prefixes.wantsMax || prefixes.wantsMin) {
+		return 0, dice.ErrInvalidRollExpression
+	}
+
+	rolls := rollDice(r, match[1], match[2])
+	sum := 0
+	lowest, highest := 0, 0
+	for i, roll := range rolls {
+		sum += roll
+		if i == 0 || roll < lowest {
+			lowest = roll
+		}
+		if i == 0 || roll > highest {
+			highest = roll
+		}
+	}
+
+	modifier := termModifier(match[3], match[4])
+	switch {
+	case prefixes.wantsMax:
+		return highest + modifier, nil
+	case prefixes.wantsMin:
+		return lowest + modifier, nil
+	}
+
+	sum += modifier
+	if prefixes.dropLowest {
+		sum -= lowest
+	}
+	if prefixes.dropHighest {
+		sum -= highest
+	}
+
+	if hasSecondExpression {
+		secondSum := termModifier(match[9], match[10])
+		for _, roll := range rollDice(r, match[7], match[8]) {
+			secondSum += roll
+		}
+		if match[6] == "-" {
+			secondSum = -secondSum
+		}
+		sum += secondSum
+	}
+
+	if prefixes.halfResult {
+		return sum / 2, nil
+	}
+
+	if prefixes.doubleResult {
+		return sum * 2, nil
+	}
+
+	return sum, nil
+}
+
+//rollPrefixes are the prefixes of a roll expression accepted by dice.RollExpression.
+type rollPrefixes struct {
+	wantsMax, wantsMin, halfResult, doubleResult, dropLowest, dropHighest bool
+}
+
+//parseRollPrefixes returns the prefixes of expression and the expression without them. Prefixes are checked in the
+//same order as dice.RollExpression, so the same expressions are accepted.
+func parseRollPrefixes(expression string) (rollPrefixes, string) {
+	var prefixes rollPrefixes
+	for _, prefix := range []struct {
+		value string
+		flag  *bool
+	}{
+		{"max:", &prefixes.wantsMax},
+		{"min:", &prefixes.wantsMin},
+		{"half:", &prefixes.halfResult},
+		{"dub:", &prefixes.doubleResult},
+		{"dropL:", &prefixes.dropLowest},
+		{"dropH:", &prefixes.dropHighest},
+	} {
+		if strings.HasPrefix(expression, prefix.value) {
+			*prefix.flag = true
+			expression = strings.ReplaceAll(expression, prefix.value, "")
+		}
+	}
+
+	return prefixes, expression
+}
+
+//rollDice rolls a single #d# term of a roll expression using r.
+func rollDice(r Rand, number, sides string) []int {
+	n := 1
+	if number != "" {
+		n, _ = strconv.Atoi(number)
+	}
+	s, _ := strconv.Atoi(sides)
+
+	rolls := make([]int, n)
+	for i := range rolls {
+		rolls[i] = r.RandomRange(1, s)
+	}
+
+	return rolls
+}
+
+//rollStringWith replaces every braced roll expression in value (e.g. {{1d6}}) with its rolled value using r,
+//the same as dice.RollString. A nil r uses dice.RollString.
+func rollStringWith(r Rand, value string) string {
+	if r == nil {
+		return dice.RollString(value)
+	}
+
+	return dice.ContainsRollExpressionBracedRE.ReplaceAllStringFunc(value, func(m string) string {
+		expression := strings.Trim(strings.TrimSuffix(strings.TrimPrefix(m, "{{"), "}}"), " ")
+		sum, _ := rollWith(r, expression)
+		return strconv.Itoa(sum)
+	})
+}
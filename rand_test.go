@@ -0,0 +1,100 @@
+package tables
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_rollWith(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		low        int
+		high       int
+	}{
+		{name: "validate a single die", expression: "d6", low: 1, high: 6},
+		{name: "validate multiple dice with a modifier", expression: "3d4+2", low: 5, high: 14},
+		{name: "validate an expression pair", expression: "1d6-1d4", low: -3, high: 5},
+		{name: "validate the highest die", expression: "max:3d6", low: 1, high: 6},
+		{name: "validate the lowest die", expression: "min:3d6+1", low: 2, high: 7},
+		{name: "validate dropping the lowest die", expression: "dropL:4d6", low: 3, high: 18},
+		{name: "validate dropping the highest die", expression: "dropH:2d6", low: 1, high: 6},
+		{name: "validate halving the result", expression: "half:d4", low: 0, high: 2},
+		{name: "validate doubling the result", expression: "dub:d4", low: 2, high: 8},
+		{name: "validate the highest die is not halved", expression: "max:half:3d6", low: 1, high: 6},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewRand(42)
+			for i := 0; i < 100; i++ {
+				got, err := rollWith(r, test.expression)
+				if err != nil {
+					t.Fatalf("unexpected error, %s", err)
+				}
+				if got < test.low || got > test.high {
+					t.Fatalf("want %d-%d, got %d", test.low, test.high, got)
+				}
+			}
+		})
+	}
+
+	t.Run("validate the dice package is used without a source", func(t *testing.T) {
+		got, err := rollWith(nil, "1d1+1")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got != 2 {
+			t.Errorf("want 2, got %d", got)
+		}
+	})
+
+	t.Run("validate an error is returned for an invalid roll expression", func(t *testing.T) {
+		_, err := rollWith(NewRand(42), "max:d6+d6")
+		if err == nil {
+			t.Errorf("expected an error, error was nil")
+		}
+	})
+}
+
+func Test_rollStringWith(t *testing.T) {
+	t.Run("validate every braced roll expression is rolled", func(t *testing.T) {
+		got := rollStringWith(NewRand(42), "{{1d1}} and {{ 2d1+1 }} but not 1d6")
+		want := "1 and 3 but not 1d6"
+		if got != want {
+			t.Errorf("want %s, got %s", want, got)
+		}
+	})
+
+	t.Run("validate seeded rolls are repeated", func(t *testing.T) {
+		want := rollStringWith(NewRand(7), "{{1d100}} {{1d100}} {{1d100}}")
+		got := rollStringWith(NewRand(7), "{{1d100}} {{1d100}} {{1d100}}")
+		if got != want {
+			t.Errorf("want %s, got %s", want, got)
+		}
+	})
+}
+
+func TestTable_ExpressionWith(t *testing.T) {
+	t.Run("validate seeded table expressions are repeated", func(t *testing.T) {
+		var records [][]string
+		for i := 1; i <= 20; i++ {
+			records = append(records, []string{strconv.Itoa(i), "Result {{1d1000}}"})
+		}
+		table, _ := Load(append([][]string{{"D20", "Result"}}, records...), "test", "Test", "d20")
+
+		want, err := table.ExpressionWith(NewRand(1234), "5?test")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		got, err := table.ExpressionWith(NewRand(1234), "5?test")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+}
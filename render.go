@@ -0,0 +1,485 @@
+package tables
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const ErrUnsupportedRenderer = TableError("renderer is not supported")
+
+//Renderer is how cells are rendered by RenderCell and Render.
+type Renderer string
+
+//Cells can use inline Markdown: **strong**, *emphasis* (or _emphasis_), `code`, [links](https://example.com), and line
+//breaks (a new line or <br>). Anything else is left as text, escaped for HTML.
+const (
+	//RenderHTML escapes cells and converts inline Markdown to HTML, links are only kept for http, https, and mailto URLs.
+	RenderHTML Renderer = "html"
+	//RenderText removes inline Markdown and control characters, links are written as their text followed by their URL.
+	RenderText Renderer = "text"
+	//RenderANSI styles inline Markdown with ANSI escape codes for terminals, control characters in cells are removed.
+	RenderANSI Renderer = "ansi"
+)
+
+//inlineStyle is the inline Markdown styles applied to text.
+type inlineStyle uint8
+
+const (
+	styleStrong inlineStyle = 1 << iota
+	styleEmphasis
+	styleCode
+	styleLink
+)
+
+//inline is a span of inline Markdown.
+type inline struct {
+	style    inlineStyle
+	text     string
+	url      string
+	children []inline
+	//lineBreak is true for a line break, the span has no text or children
+	lineBreak bool
+}
+
+//run is text in a single style, inline spans are flattened to runs to render text and ANSI.
+type run struct {
+	text  string
+	style inlineStyle
+}
+
+//RenderCell returns a cell (e.g. a rolled result) rendered using renderer. Cells are rendered as they are, so roll
+//expressions should be rolled first.
+func RenderCell(renderer Renderer, cell string) (string, error) {
+	spans := parseInline(cell)
+	switch renderer {
+	case RenderHTML:
+		var b strings.Builder
+		writeInlineHTML(&b, spans)
+		return b.String(), nil
+	case RenderText, RenderANSI:
+		return writeRuns(renderer, flattenInline(spans, 0)), nil
+	}
+
+	return "", ErrUnsupportedRenderer
+}
+
+//Render writes records (e.g. from Records or Expression) as a table using renderer, the first record is the header.
+//Text and ANSI tables are aligned in columns, and cells are wrapped to width characters if width is more than 0.
+//HTML tables are written as a table element, with line breaks in cells kept as br elements.
+func Render(w io.Writer, renderer Renderer, records [][]string, width int) error {
+	switch renderer {
+	case RenderHTML:
+		return renderHTMLTable(w, records)
+	case RenderText, RenderANSI:
+		return renderColumns(w, renderer, records, width)
+	}
+
+	return ErrUnsupportedRenderer
+}
+
+func renderHTMLTable(w io.Writer, records [][]string) error {
+	var b strings.Builder
+	b.WriteString("<table>\n")
+	for i, record := range records {
+		cell := "td"
+		switch i {
+		case 0:
+			b.WriteString("<thead>\n")
+			cell = "th"
+		case 1:
+			b.WriteString("<tbody>\n")
+		}
+
+		b.WriteString("<tr>")
+		for _, value := range record {
+			fmt.Fprintf(&b, "<%s>", cell)
+			writeInlineHTML(&b, parseInline(value))
+			fmt.Fprintf(&b, "</%s>", cell)
+		}
+		b.WriteString("</tr>\n")
+
+		if i == 0 {
+			b.WriteString("</thead>\n")
+		}
+	}
+	if len(records) > 1 {
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func renderColumns(w io.Writer, renderer Renderer, records [][]string, width int) error {
+	columns := 0
+	for _, record := range records {
+		columns = max(columns, len(record))
+	}
+
+	//each cell is wrapped into lines of runs, and each column is as wide as its widest line
+	cells := make([][][][]run, len(records))
+	widths := make([]int, columns)
+	for i, record := range records {
+		cells[i] = make([][][]run, len(record))
+		for j, value := range record {
+			runs := flattenInline(parseInline(value), 0)
+			if i == 0 && renderer == RenderANSI {
+				for k := range runs {
+					runs[k].style |= styleStrong
+				}
+			}
+			cells[i][j] = wrapRuns(runs, width)
+			for _, line := range cells[i][j] {
+				widths[j] = max(widths[j], runsWidth(line))
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, record := range cells {
+		lines := 1
+		for _, cell := range record {
+			lines = max(lines, len(cell))
+		}
+
+		for line := 0; line < lines; line++ {
+			var row strings.Builder
+			pad := 0
+			for j, cell := range record {
+				if j > 0 {
+					pad += 2
+				}
+				if line >= len(cell) || len(cell[line]) == 0 {
+					pad += widths[j]
+					continue
+				}
+				row.WriteString(strings.Repeat(" ", pad))
+				row.WriteString(writeRuns(renderer, cell[line]))
+				pad = widths[j] - runsWidth(cell[line])
+			}
+			b.WriteString(row.String())
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+//parseInline parses the inline Markdown in text.
+func parseInline(text string) []inline {
+	var spans []inline
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			spans = append(spans, inline{text: plain.String()})
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\*_`[]()<>#", rune(rest[1])):
+			plain.WriteByte(rest[1])
+			i += 2
+			continue
+		case rest[0] == '\n':
+			flush()
+			spans = append(spans, inline{lineBreak: true})
+			i++
+			continue
+		case rest[0] == '\r':
+			i++
+			continue
+		case rest[0] == '<':
+			if n := lineBreakTag(rest); n > 0 {
+				flush()
+				spans = append(spans, inline{lineBreak: true})
+				i += n
+				continue
+			}
+		case strings.HasPrefix(rest, "**"):
+			if end := closingDelimiter(rest[2:], "**"); end > 0 {
+				flush()
+				spans = append(spans, inline{style: styleStrong, children: parseInline(rest[2 : 2+end])})
+				i += end + 4
+				continue
+			}
+		case rest[0] == '*' || (rest[0] == '_' && (i == 0 || !isWordByte(text[i-1]))):
+			if end := closingDelimiter(rest[1:], rest[:1]); end > 0 && (rest[0] == '*' || 1+end+1 >= len(rest) || !isWordByte(rest[1+end+1])) {
+				flush()
+				spans = append(spans, inline{style: styleEmphasis, children: parseInline(rest[1 : 1+end])})
+				i += end + 2
+				continue
+			}
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+				flush()
+				spans = append(spans, inline{style: styleCode, text: rest[1 : 1+end]})
+				i += end + 2
+				continue
+			}
+		case rest[0] == '[':
+			if label, url, n, ok := parseLink(rest); ok {
+				flush()
+				spans = append(spans, inline{style: styleLink, url: url, children: parseInline(label)})
+				i += n
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		plain.WriteString(rest[:size])
+		i += size
+	}
+	flush()
+
+	return spans
+}
+
+//closingDelimiter returns the index of the delimiter closing an inline span in text, or -1 if there isn't one. The
+//span can't start or end with a space.
+func closingDelimiter(text, delimiter string) int {
+	if text == "" || text[0] == ' ' {
+		return -1
+	}
+
+	for i := 1; i < len(text); i++ {
+		if strings.HasPrefix(text[i:], delimiter) && text[i-1] != ' ' && text[i-1] != '\\' {
+			//** is not an emphasis delimiter
+			if delimiter == "*" && strings.HasPrefix(text[i:], "**") {
+				i++
+				continue
+			}
+			//** closes at the end of a run of asterisks, so emphasis inside it can be closed first (e.g. **a *b***)
+			for delimiter == "**" && i+2 < len(text) && text[i+2] == '*' {
+				i++
+			}
+			return i
+		}
+	}
+
+	return -1
+}
+
+//parseLink parses a link at the start of text, returning its label, URL, and length.
+func parseLink(text string) (string, string, int, bool) {
+	closeLabel := strings.Index(text, "](")
+	if closeLabel < 1 {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexByte(text[closeLabel+2:], ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+
+	url := strings.TrimSpace(text[closeLabel+2 : closeLabel+2+closeURL])
+	if url == "" || strings.ContainsAny(url, " \n") {
+		return "", "", 0, false
+	}
+
+	return text[1:closeLabel], url, closeLabel + 3 + closeURL, true
+}
+
+//lineBreakTag returns the length of a br tag at the start of text, or 0 if there isn't one.
+func lineBreakTag(text string) int {
+	for _, tag := range []string{"<br>", "<br/>", "<br />"} {
+		if len(text) >= len(tag) && strings.EqualFold(text[:len(tag)], tag) {
+			return len(tag)
+		}
+	}
+
+	return 0
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= utf8.RuneSelf || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+}
+
+//safeURL returns true for URLs that can be linked in HTML.
+func safeURL(url string) bool {
+	lower := strings.ToLower(url)
+	for _, scheme := range []string{"http://", "https://", "mailto:"} {
+		if strings.HasPrefix(lower, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeInlineHTML(b *strings.Builder, spans []inline) {
+	for _, span := range spans {
+		switch {
+		case span.lineBreak:
+			b.WriteString("<br>")
+		case span.style == styleStrong:
+			b.WriteString("<strong>")
+			writeInlineHTML(b, span.children)
+			b.WriteString("</strong>")
+		case span.style == styleEmphasis:
+			b.WriteString("<em>")
+			writeInlineHTML(b, span.children)
+			b.WriteString("</em>")
+		case span.style == styleCode:
+			fmt.Fprintf(b, "<code>%s</code>", html.EscapeString(span.text))
+		case span.style == styleLink && safeURL(span.url):
+			fmt.Fprintf(b, `<a href="%s">`, html.EscapeString(span.url))
+			writeInlineHTML(b, span.children)
+			b.WriteString("</a>")
+		case span.style == styleLink:
+			writeInlineHTML(b, span.children)
+		default:
+			b.WriteString(html.EscapeString(span.text))
+		}
+	}
+}
+
+//flattenInline returns spans as runs of text, with style applied to every run. Line breaks are runs of a new line,
+//and links are followed by their URL. Control characters are removed (see stripControl).
+func flattenInline(spans []inline, style inlineStyle) []run {
+	var runs []run
+	for _, span := range spans {
+		switch {
+		case span.lineBreak:
+			runs = append(runs, run{text: "\n"})
+		case span.children != nil:
+			runs = append(runs, flattenInline(span.children, style|span.style)...)
+			if span.style == styleLink {
+				runs = append(runs, run{text: " (" + stripControl(span.url) + ")", style: style})
+			}
+		default:
+			runs = append(runs, run{text: stripControl(span.text), style: style | span.style})
+		}
+	}
+
+	return runs
+}
+
+//stripControl returns text without control characters, so a cell can't send its own escape codes to a terminal or
+//break the alignment of columns. Tabs are replaced with a space.
+func stripControl(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, text)
+}
+
+//writeRuns returns runs as text, styled with ANSI escape codes for RenderANSI. Every run resets its style so styles
+//never carry over to the text around it.
+func writeRuns(renderer Renderer, runs []run) string {
+	var b strings.Builder
+	for _, r := range runs {
+		if renderer != RenderANSI || r.style == 0 || r.text == "\n" {
+			b.WriteString(r.text)
+			continue
+		}
+
+		for _, code := range []struct {
+			style inlineStyle
+			code  string
+		}{{styleStrong, "1"}, {styleEmphasis, "3"}, {styleCode, "2"}, {styleLink, "4"}} {
+			if r.style&code.style != 0 {
+				b.WriteString("\x1b[" + code.code + "m")
+			}
+		}
+		b.WriteString(r.text)
+		b.WriteString("\x1b[0m")
+	}
+
+	return b.String()
+}
+
+//wrapRuns splits runs into lines at line breaks, and between words to keep lines to width characters if width is more
+//than 0. Words longer than width are split.
+func wrapRuns(runs []run, width int) [][]run {
+	lines := [][]run{nil}
+	lineWidth := 0
+	add := func(r run) {
+		last := len(lines) - 1
+		if n := len(lines[last]); n > 0 && lines[last][n-1].style == r.style {
+			lines[last][n-1].text += r.text
+		} else {
+			lines[last] = append(lines[last], r)
+		}
+		lineWidth += utf8.RuneCountInString(r.text)
+	}
+	newLine := func() {
+		last := len(lines) - 1
+		if n := len(lines[last]); n > 0 {
+			lines[last][n-1].text = strings.TrimRight(lines[last][n-1].text, " ")
+		}
+		lines = append(lines, nil)
+		lineWidth = 0
+	}
+
+	for _, r := range runs {
+		if r.text == "\n" {
+			newLine()
+			continue
+		}
+
+		for _, word := range splitWords(r.text) {
+			length := utf8.RuneCountInString(word)
+			if width > 0 && lineWidth+length > width && strings.TrimSpace(word) != "" && lineWidth > 0 {
+				newLine()
+			}
+			if lineWidth == 0 && strings.TrimSpace(word) == "" {
+				continue
+			}
+			for width > 0 && length > width {
+				characters := []rune(word)
+				add(run{text: string(characters[:width]), style: r.style})
+				word = string(characters[width:])
+				length -= width
+				newLine()
+			}
+			add(run{text: word, style: r.style})
+		}
+	}
+
+	last := len(lines) - 1
+	if n := len(lines[last]); n > 0 {
+		lines[last][n-1].text = strings.TrimRight(lines[last][n-1].text, " ")
+	}
+
+	return lines
+}
+
+//splitWords splits text into words and the spaces between them.
+func splitWords(text string) []string {
+	var words []string
+	start := 0
+	for i, r := range text {
+		if i > start && (r == ' ') != (text[i-1] == ' ') {
+			words = append(words, text[start:i])
+			start = i
+		}
+	}
+	if start < len(text) {
+		words = append(words, text[start:])
+	}
+
+	return words
+}
+
+//runsWidth returns the number of characters in runs.
+func runsWidth(runs []run) int {
+	width := 0
+	for _, r := range runs {
+		width += utf8.RuneCountInString(r.text)
+	}
+
+	return width
+}
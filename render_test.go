@@ -0,0 +1,118 @@
+package tables
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRenderCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer Renderer
+		cell     string
+		want     string
+	}{
+		{"validate html is escaped", RenderHTML, `<script>alert("gold")</script> & more`, "&lt;script&gt;alert(&#34;gold&#34;)&lt;/script&gt; &amp; more"},
+		{"validate html inline markdown", RenderHTML, "**Magic** *sword*, _cursed_ `+1`", "<strong>Magic</strong> <em>sword</em>, <em>cursed</em> <code>+1</code>"},
+		{"validate html nested markdown", RenderHTML, "**a *very* big** sword", "<strong>a <em>very</em> big</strong> sword"},
+		{"validate html links", RenderHTML, "[SRD](https://example.com/srd?a=1&b=2)", `<a href="https://example.com/srd?a=1&amp;b=2">SRD</a>`},
+		{"validate html unsafe links are text", RenderHTML, "[click](javascript:alert(1))", "click)"},
+		{"validate html line breaks", RenderHTML, "Rain\nWind<br>Hail<BR />Snow", "Rain<br>Wind<br>Hail<br>Snow"},
+		{"validate html escaped markdown", RenderHTML, `\*not emphasis\* 5 * 3 * 2`, "*not emphasis* 5 * 3 * 2"},
+		{"validate html underscores in words", RenderHTML, "snake_case_name", "snake_case_name"},
+		{"validate html unclosed markdown", RenderHTML, "**bold and [link", "**bold and [link"},
+		{"validate html code is not markdown", RenderHTML, "`**<b>**`", "<code>**&lt;b&gt;**</code>"},
+		{"validate text removes markdown", RenderText, "**Magic** *sword* `+1`", "Magic sword +1"},
+		{"validate text links", RenderText, "see [SRD](https://example.com)", "see SRD (https://example.com)"},
+		{"validate text line breaks", RenderText, "Rain<br>Wind", "Rain\nWind"},
+		{"validate ansi styles", RenderANSI, "**Magic** *sword* `+1`", "\x1b[1mMagic\x1b[0m \x1b[3msword\x1b[0m \x1b[2m+1\x1b[0m"},
+		{"validate ansi nested styles", RenderANSI, "**a *b***", "\x1b[1ma \x1b[0m\x1b[1m\x1b[3mb\x1b[0m"},
+		{"validate ansi links", RenderANSI, "[SRD](https://example.com)", "\x1b[4mSRD\x1b[0m (https://example.com)"},
+		{"validate text control characters are removed", RenderText, "Gold\x1b[2J\tcoins\r\x07", "Gold[2J coins"},
+		{"validate ansi control characters are removed", RenderANSI, "*\x1b[31mred\u009b*", "\x1b[3m[31mred\x1b[0m"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := RenderCell(test.renderer, test.cell)
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+			if test.want != got {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+
+	t.Run("validate an error is returned for an unsupported renderer", func(t *testing.T) {
+		_, err := RenderCell("pdf", "gold")
+		if err != ErrUnsupportedRenderer {
+			t.Errorf("want %v, got %v", ErrUnsupportedRenderer, err)
+		}
+	})
+}
+
+func TestRender(t *testing.T) {
+	records := [][]string{
+		{"D4", "Treasure"},
+		{"1-3", "**Gold** coins"},
+		{"4", "A *cursed* sword that whispers at night<br>[Details](https://example.com)"},
+	}
+
+	tests := []struct {
+		name     string
+		renderer Renderer
+		records  [][]string
+		width    int
+		want     string
+	}{
+		{"validate text columns are aligned", RenderText, records[:2], 0, "D4   Treasure\n1-3  Gold coins\n"},
+		{"validate text columns are wrapped", RenderText, records, 16, "D4   Treasure\n1-3  Gold coins\n4    A cursed sword\n     that whispers at\n     night\n     Details\n     (https://example\n     .com)\n"},
+		{"validate text empty cells", RenderText, [][]string{{"A", "B", "C"}, {"", "", "c"}, {"a", "", ""}}, 0, "A  B  C\n      c\na\n"},
+		{"validate ansi headers are bold", RenderANSI, records[:2], 0, "\x1b[1mD4\x1b[0m   \x1b[1mTreasure\x1b[0m\n1-3  \x1b[1mGold\x1b[0m coins\n"},
+		{"validate ansi styles do not span wrapped lines", RenderANSI, [][]string{{"Name"}, {"*very cold*"}}, 5, "\x1b[1mName\x1b[0m\n\x1b[3mvery\x1b[0m\n\x1b[3mcold\x1b[0m\n"},
+		{"validate html table", RenderHTML, records[:2], 0, "<table>\n<thead>\n<tr><th>D4</th><th>Treasure</th></tr>\n</thead>\n<tbody>\n<tr><td>1-3</td><td><strong>Gold</strong> coins</td></tr>\n</tbody>\n</table>\n"},
+		{"validate html table without rows", RenderHTML, records[:1], 0, "<table>\n<thead>\n<tr><th>D4</th><th>Treasure</th></tr>\n</thead>\n</table>\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := Render(&b, test.renderer, test.records, test.width)
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+			if test.want != b.String() {
+				t.Errorf("want %q, got %q", test.want, b.String())
+			}
+		})
+	}
+
+	t.Run("validate rolled records are rendered", func(t *testing.T) {
+		table, err := Load([][]string{{"D1", "Loot"}, {"1", "**{{1d1}}** <gp>"}}, "loot", "Loot", "d1")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		rolled, err := table.Expression("1?loot")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		var b bytes.Buffer
+		err = Render(&b, RenderHTML, rolled, 0)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		want := "<table>\n<thead>\n<tr><th>D1</th><th>Loot</th></tr>\n</thead>\n<tbody>\n<tr><td>1</td><td><strong>1</strong> &lt;gp&gt;</td></tr>\n</tbody>\n</table>\n"
+		if want != b.String() {
+			t.Errorf("want %q, got %q", want, b.String())
+		}
+	})
+
+	t.Run("validate an error is returned for an unsupported renderer", func(t *testing.T) {
+		err := Render(&bytes.Buffer{}, "pdf", records, 0)
+		if err != ErrUnsupportedRenderer {
+			t.Errorf("want %v, got %v", ErrUnsupportedRenderer, err)
+		}
+	})
+}
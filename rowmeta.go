@@ -0,0 +1,199 @@
+package tables
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//MetaColumnPrefix marks a column as row meta data (e.g. @source, @page), the column's cells are loaded into each row's
+//Meta using the header without the prefix as the key.
+const MetaColumnPrefix = "@"
+
+//Common row meta data keys.
+const (
+	RowMetaSource = "source"
+	RowMetaPage   = "page"
+	RowMetaNotes  = "notes"
+	RowMetaRarity = "rarity"
+	RowMetaImage  = "image"
+)
+
+//sidecarExtensions are the extensions of the files LoadFile reads row meta data from, next to the table's file
+//(e.g. loot.meta.yaml for loot.csv).
+var sidecarExtensions = []string{".meta.yaml", ".meta.yml", ".meta.json"}
+
+//SetRowMeta sets the meta data value of key for the row at index, an empty value removes key.
+func (t *Table) SetRowMeta(index int, key, value string) error {
+	if index < 0 || index >= len(t.Rows) {
+		return ErrRowDoesNotExist
+	}
+
+	meta := make(map[string]string, len(t.Rows[index].Meta)+1)
+	for k, v := range t.Rows[index].Meta {
+		meta[k] = v
+	}
+	meta[key] = value
+	if value == "" {
+		delete(meta, key)
+	}
+	if len(meta) == 0 {
+		meta = nil
+	}
+
+	rows := append([]Row(nil), t.Rows...)
+	rows[index].Meta = meta
+	t.Rows = rows
+
+	return nil
+}
+
+//AddRowMeta adds meta data to rows using their key, the roll of rollable tables (e.g. 1-3) or the first column of
+//other tables. ErrRowDoesNotExist is returned if a key does not match a row.
+func (t *Table) AddRowMeta(meta map[string]map[string]string) error {
+	table := *t
+	for key, values := range meta {
+		index := -1
+		for i, row := range table.Rows {
+			if inheritKey(row, table.Meta.RollableTable) == key {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return ErrRowDoesNotExist
+		}
+
+		for k, v := range values {
+			err := table.SetRowMeta(index, k, v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	t.Rows = table.Rows
+
+	return nil
+}
+
+//RowMetaKeys returns every row meta data key used by the table, sorted.
+func (t Table) RowMetaKeys() []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, row := range t.Rows {
+		for key := range row.Meta {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+//RecordsWithMeta returns the table's records the same as Records, followed by a column for each row meta data key
+//(see MetaColumnPrefix). The records can be read back by Load.
+func (t Table) RecordsWithMeta() [][]string {
+	records := t.Records()
+	keys := t.RowMetaKeys()
+	if len(keys) == 0 {
+		return records
+	}
+
+	headers := cloneStrings(records[0])
+	for _, key := range keys {
+		headers = append(headers, MetaColumnPrefix+key)
+	}
+	records[0] = headers
+
+	for i, row := range t.Rows {
+		record := cloneStrings(row.Results)
+		for _, key := range keys {
+			record = append(record, row.Meta[key])
+		}
+		records[i+1] = record
+	}
+
+	return records
+}
+
+//metaColumns returns the index of every meta data column in headers.
+func metaColumns(headers []string) []int {
+	var columns []int
+	for i, header := range headers {
+		if strings.HasPrefix(header, MetaColumnPrefix) && len(header) > len(MetaColumnPrefix) {
+			columns = append(columns, i)
+		}
+	}
+
+	return columns
+}
+
+//splitMeta returns record without the meta data columns, and the meta data in those columns. Empty cells are skipped.
+func splitMeta(record []string, headers []string, columns []int) ([]string, map[string]string) {
+	if len(columns) == 0 {
+		return record, nil
+	}
+
+	var meta map[string]string
+	results := make([]string, 0, len(record))
+	next := 0
+	for i, value := range record {
+		if next < len(columns) && columns[next] == i {
+			next++
+			if value == "" {
+				continue
+			}
+			if meta == nil {
+				meta = make(map[string]string)
+			}
+			meta[strings.TrimPrefix(headers[i], MetaColumnPrefix)] = value
+			continue
+		}
+		results = append(results, value)
+	}
+
+	return results, meta
+}
+
+//loadSidecar returns the row meta data in the sidecar file of the table at path, or nil if it does not have one.
+//A sidecar maps row keys to their meta data.
+func loadSidecar(path string) (map[string]map[string]string, error) {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	for _, extension := range sidecarExtensions {
+		data, err := os.ReadFile(base + extension)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var meta map[string]map[string]string
+		err = yaml.Unmarshal(data, &meta)
+		if err != nil {
+			return nil, err
+		}
+		return meta, nil
+	}
+
+	return nil, nil
+}
+
+//sidecarFile returns true if path is a sidecar file.
+func sidecarFile(path string) bool {
+	for _, extension := range sidecarExtensions {
+		if strings.HasSuffix(strings.ToLower(path), extension) {
+			return true
+		}
+	}
+
+	return false
+}
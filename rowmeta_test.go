@@ -0,0 +1,202 @@
+package tables
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var sourcedCSV = [][]string{
+	{"D4", "Monster", "@source", "@page"},
+	{"1-2", "Goblin", "Core", "12"},
+	{"3", "Wolf", "", ""},
+	{"4", "Owlbear", "Bestiary", "88"},
+}
+
+func testSourcedTable(t *testing.T) Table {
+	table, err := Load(sourcedCSV, "monsters", "Monsters", "d4")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	return table
+}
+
+func TestLoad_rowMeta(t *testing.T) {
+	table := testSourcedTable(t)
+
+	t.Run("validate meta data columns are loaded into rows", func(t *testing.T) {
+		want := []map[string]string{{RowMetaSource: "Core", RowMetaPage: "12"}, nil, {RowMetaSource: "Bestiary", RowMetaPage: "88"}}
+		for i, row := range table.Rows {
+			if !reflect.DeepEqual(want[i], row.Meta) {
+				t.Errorf("want %v, got %v", want[i], row.Meta)
+			}
+		}
+
+		err := table.Validate()
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+	})
+
+	t.Run("validate meta data is not part of the records", func(t *testing.T) {
+		want := [][]string{{"D4", "Monster"}, {"1-2", "Goblin"}, {"3", "Wolf"}, {"4", "Owlbear"}}
+		if !reflect.DeepEqual(want, table.Records()) {
+			t.Errorf("want %v, got %v", want, table.Records())
+		}
+
+		got, _ := table.Expression("4#monsters")
+		if want := [][]string{{"D4", "Monster"}, {"4", "Owlbear"}}; !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate meta data can be included in the records", func(t *testing.T) {
+		want := [][]string{{"D4", "Monster", "@page", "@source"}, {"1-2", "Goblin", "12", "Core"}, {"3", "Wolf", "", ""}, {"4", "Owlbear", "88", "Bestiary"}}
+		if !reflect.DeepEqual(want, table.RecordsWithMeta()) {
+			t.Errorf("want %v, got %v", want, table.RecordsWithMeta())
+		}
+	})
+
+	t.Run("validate meta data is kept when packed and unpacked", func(t *testing.T) {
+		_, data := table.Pack()
+
+		got := Table{}
+		got.Unpack(data)
+		if !reflect.DeepEqual(table, got) {
+			t.Errorf("want %v, got %v", table, got)
+		}
+	})
+
+	t.Run("validate meta data is kept when encoded and decoded", func(t *testing.T) {
+		for _, format := range []Format{FormatCSV, FormatMarkdown, FormatJSON, FormatYAML} {
+			var b bytes.Buffer
+			err := Encode(&b, format, table)
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+
+			got, err := Decode(&b, format, "monsters")
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+			for i, row := range got.Rows {
+				if !reflect.DeepEqual(table.Rows[i].Meta, row.Meta) {
+					t.Errorf("want %v, got %v (%s)", table.Rows[i].Meta, row.Meta, format)
+				}
+			}
+		}
+	})
+}
+
+func TestTable_SetRowMeta(t *testing.T) {
+	t.Run("validate meta data is set without changing copies of the table", func(t *testing.T) {
+		table := testSourcedTable(t)
+		original := table.Clone()
+
+		err := table.SetRowMeta(1, RowMetaNotes, "Only at night.")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		err = table.SetRowMeta(0, RowMetaPage, "")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if want := map[string]string{RowMetaNotes: "Only at night."}; !reflect.DeepEqual(want, table.Rows[1].Meta) {
+			t.Errorf("want %v, got %v", want, table.Rows[1].Meta)
+		}
+		if want := map[string]string{RowMetaSource: "Core"}; !reflect.DeepEqual(want, table.Rows[0].Meta) {
+			t.Errorf("want %v, got %v", want, table.Rows[0].Meta)
+		}
+		if original.Rows[1].Meta != nil || original.Rows[0].Meta[RowMetaPage] != "12" {
+			t.Errorf("want the original meta data, got %v", original.Rows)
+		}
+	})
+
+	t.Run("validate meta data is kept by edits", func(t *testing.T) {
+		table := testSourcedTable(t)
+		err := table.UpdateCell(0, 1, "Hobgoblin")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if table.Rows[0].Meta[RowMetaSource] != "Core" {
+			t.Errorf("want Core, got %v", table.Rows[0].Meta)
+		}
+	})
+
+	t.Run("validate an error is returned for a row that does not exist", func(t *testing.T) {
+		table := testSourcedTable(t)
+		err := table.SetRowMeta(3, RowMetaNotes, "Nope")
+		if err != ErrRowDoesNotExist {
+			t.Errorf("want %v, got %v", ErrRowDoesNotExist, err)
+		}
+	})
+}
+
+func TestTable_AddRowMeta(t *testing.T) {
+	t.Run("validate meta data is added by row key", func(t *testing.T) {
+		table := testSourcedTable(t)
+		err := table.AddRowMeta(map[string]map[string]string{"3": {RowMetaRarity: "common"}, "1-2": {RowMetaImage: "goblin.png"}})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if want := map[string]string{RowMetaRarity: "common"}; !reflect.DeepEqual(want, table.Rows[1].Meta) {
+			t.Errorf("want %v, got %v", want, table.Rows[1].Meta)
+		}
+		if table.Rows[0].Meta[RowMetaImage] != "goblin.png" || table.Rows[0].Meta[RowMetaSource] != "Core" {
+			t.Errorf("want added meta data, got %v", table.Rows[0].Meta)
+		}
+	})
+
+	t.Run("validate an error is returned for a key that does not match a row", func(t *testing.T) {
+		table := testSourcedTable(t)
+		err := table.AddRowMeta(map[string]map[string]string{"3": {RowMetaRarity: "common"}, "5": {RowMetaRarity: "rare"}})
+		if err != ErrRowDoesNotExist {
+			t.Errorf("want %v, got %v", ErrRowDoesNotExist, err)
+		}
+		if table.Rows[1].Meta != nil {
+			t.Errorf("want no changes, got %v", table.Rows[1].Meta)
+		}
+	})
+}
+
+func TestLoadFile_sidecar(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"abilities.csv":       "Ability,Description\nFUN,Funness\nBTR,Bitterness\n",
+		"abilities.meta.yaml": "BTR:\n  notes: GM only\n  page: \"7\"\n",
+	}
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+	}
+
+	t.Run("validate row meta data is read from a sidecar file", func(t *testing.T) {
+		table, err := LoadFile(filepath.Join(dir, "abilities.csv"))
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		want := map[string]string{RowMetaNotes: "GM only", RowMetaPage: "7"}
+		if !reflect.DeepEqual(want, table.Rows[1].Meta) {
+			t.Errorf("want %v, got %v", want, table.Rows[1].Meta)
+		}
+	})
+
+	t.Run("validate sidecar files are not loaded as tables", func(t *testing.T) {
+		tables, err := LoadDir(dir)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if len(tables) != 1 || tables[0].Meta.Name != "abilities" {
+			t.Errorf("want only abilities, got %v", tables)
+		}
+	})
+}
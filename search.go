@@ -0,0 +1,321 @@
+package tables
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+//SearchField is a part of a table that search terms are matched against.
+type SearchField string
+
+//Fields are listed by their weight, matches in earlier fields rank higher.
+const (
+	SearchName        SearchField = "name"
+	SearchDisplayName SearchField = "display_name"
+	SearchTitle       SearchField = "title"
+	SearchHeaders     SearchField = "headers"
+	SearchFlavorText  SearchField = "flavor_text"
+	SearchRows        SearchField = "rows"
+)
+
+var searchWeights = map[SearchField]float64{
+	SearchName:        5,
+	SearchDisplayName: 4,
+	SearchTitle:       4,
+	SearchHeaders:     2,
+	SearchFlavorText:  2,
+	SearchRows:        1,
+}
+
+//How well a search term matches a word in a table, fuzzy matches are words within a small edit distance of the term.
+const (
+	searchExact  = 1.0
+	searchPrefix = 0.6
+	searchFuzzy  = 0.4
+)
+
+//SearchOptions limits the results of Search.
+type SearchOptions struct {
+	//Campaigns limits results to tables in these campaigns, results are not limited if it is empty.
+	Campaigns []string
+	//Limit is the maximum number of results, results are not limited if it is 0.
+	Limit int
+	//Audience limits matches to the columns it can see (see Visible), every column is matched if it is empty.
+	Audience Audience
+}
+
+//SearchResult is a table that matched a search.
+type SearchResult struct {
+	Name     string
+	Campaign string
+	Score    float64
+	//Fields are the fields that matched, in the order of SearchField.
+	Fields []SearchField
+	//Rows are the indexes of the rows that matched.
+	Rows []int
+}
+
+//Search returns the tables with every word of query in their name, display name, title, flavor text, headers, or
+//rows, ordered by score. Words match whole words, the start of words (e.g. mim matches mimic), or words with a typo
+//(e.g. mimik matches mimic), and whole words and more important fields (see SearchField) score higher. The search
+//index is updated as tables are added and removed, so searching never reads the tables themselves. Tables are
+//searched resolved against their parents (see Resolve), and only the meta data of tables that can't be resolved is
+//searched.
+func (l *Library) Search(query string, options SearchOptions) []SearchResult {
+	return l.index().search(query, options)
+}
+
+//searchIndex is an index of the words in tables. An index must not be changed once it is shared, changes are made to a
+//copy (see Library.update).
+type searchIndex struct {
+	documents map[string]*searchDocument
+}
+
+//searchDocument holds where each word appears in a table.
+type searchDocument struct {
+	name     string
+	campaign string
+	words    map[string][]searchHit
+}
+
+//searchHit is a field a word appears in, row is the row's index for SearchRows. Hits in a column that is limited to
+//an audience have that audience.
+type searchHit struct {
+	field    SearchField
+	row      int
+	audience Audience
+}
+
+//copy returns a copy of the index that can be changed, the documents are shared because they are never changed.
+func (s *searchIndex) copy() *searchIndex {
+	index := &searchIndex{documents: make(map[string]*searchDocument)}
+	if s != nil {
+		for name, document := range s.documents {
+			index.documents[name] = document
+		}
+	}
+
+	return index
+}
+
+//update indexes the tables with the provided names again, along with every table that inherits from them, since a
+//change to a parent changes its children. Tables that are no longer in tables are removed from the index.
+func (s *searchIndex) update(tables map[string]Table, names ...string) {
+	changed := make(map[string]bool, len(names))
+	for _, name := range names {
+		changed[name] = true
+	}
+
+	for name := range tables {
+		//a table's ancestors are followed at most once each, so a cycle ends the search
+		for ancestor, depth := name, 0; ancestor != "" && depth <= len(tables); depth++ {
+			if changed[ancestor] {
+				s.add(tables, name)
+				break
+			}
+			ancestor = tables[ancestor].Meta.Parent
+		}
+	}
+	for _, name := range names {
+		if _, ok := tables[name]; !ok {
+			delete(s.documents, name)
+		}
+	}
+}
+
+//add indexes the table with the provided name, resolved against its parents. Only the meta data of a table that
+//can't be resolved is indexed, since it isn't known which of its columns are hidden.
+func (s *searchIndex) add(tables map[string]Table, name string) {
+	if s.documents == nil {
+		s.documents = make(map[string]*searchDocument)
+	}
+
+	table, err := resolve(tables, name, nil)
+	if err != nil {
+		table = Table{Meta: tables[name].Meta}
+		table.Meta.Headers = nil
+	}
+
+	document := &searchDocument{name: table.Meta.Name, campaign: table.Meta.Campaign, words: make(map[string][]searchHit)}
+	document.index(SearchName, -1, "", table.Meta.Name)
+	document.index(SearchDisplayName, -1, "", table.Meta.DisplayName)
+	document.index(SearchTitle, -1, "", table.Meta.Title)
+	document.index(SearchFlavorText, -1, "", table.Meta.FlavorText)
+
+	audiences := make([]Audience, len(table.Meta.Headers))
+	for i := range audiences {
+		if !table.Visible(i, "") {
+			audiences[i] = table.Meta.Visibility[i]
+		}
+	}
+	for i, header := range table.Meta.Headers {
+		document.index(SearchHeaders, -1, audiences[i], header)
+	}
+	for i, row := range table.Rows {
+		for j, result := range row.Results {
+			if j < len(audiences) {
+				document.index(SearchRows, i, audiences[j], result)
+			}
+		}
+	}
+	s.documents[name] = document
+}
+
+//index adds the words of value to the document, a word is only added once for consecutive hits in the same field,
+//row, and audience.
+func (d *searchDocument) index(field SearchField, row int, audience Audience, value string) {
+	hit := searchHit{field: field, row: row, audience: audience}
+	for _, word := range searchWords(value) {
+		hits := d.words[word]
+		if n := len(hits); n > 0 && hits[n-1] == hit {
+			continue
+		}
+		d.words[word] = append(hits, hit)
+	}
+}
+
+func (s *searchIndex) search(query string, options SearchOptions) []SearchResult {
+	terms := searchWords(query)
+	if s == nil || len(terms) == 0 {
+		return nil
+	}
+
+	campaigns := make(map[string]bool, len(options.Campaigns))
+	for _, campaign := range options.Campaigns {
+		campaigns[campaign] = true
+	}
+
+	var results []SearchResult
+	for _, document := range s.documents {
+		if len(campaigns) > 0 && !campaigns[document.campaign] {
+			continue
+		}
+
+		result, ok := document.match(terms, options.Audience)
+		if ok {
+			results = append(results, result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Name < results[j].Name
+	})
+	if options.Limit > 0 && len(results) > options.Limit {
+		results = results[:options.Limit]
+	}
+
+	return results
+}
+
+//match returns the document as a result if every term matches one of its words that audience can see. Each term
+//scores the best match in each field, so a term appearing in many rows does not outweigh a match in the table's name.
+func (d *searchDocument) match(terms []string, audience Audience) (SearchResult, bool) {
+	result := SearchResult{Name: d.name, Campaign: d.campaign}
+	fields := make(map[SearchField]bool)
+	rows := make(map[int]bool)
+	for _, term := range terms {
+		best := make(map[SearchField]float64)
+		for word, hits := range d.words {
+			quality := searchMatch(term, word)
+			if quality == 0 {
+				continue
+			}
+			for _, hit := range hits {
+				if audience != "" && audience != AudienceGM && hit.audience != "" && hit.audience != audience {
+					continue
+				}
+				best[hit.field] = max(best[hit.field], quality)
+				fields[hit.field] = true
+				if hit.field == SearchRows {
+					rows[hit.row] = true
+				}
+			}
+		}
+		if len(best) == 0 {
+			return SearchResult{}, false
+		}
+
+		for field, quality := range best {
+			result.Score += quality * searchWeights[field]
+		}
+	}
+
+	for _, field := range []SearchField{SearchName, SearchDisplayName, SearchTitle, SearchHeaders, SearchFlavorText, SearchRows} {
+		if fields[field] {
+			result.Fields = append(result.Fields, field)
+		}
+	}
+	for row := range rows {
+		result.Rows = append(result.Rows, row)
+	}
+	sort.Ints(result.Rows)
+
+	return result, true
+}
+
+//searchMatch returns how well term matches word, or 0 if it does not match. Terms of 2 or more letters match the
+//start of words, and terms of 4 or more letters match words with 1 typo (2 for 8 or more letters).
+func searchMatch(term, word string) float64 {
+	switch {
+	case term == word:
+		return searchExact
+	case len(term) >= 2 && strings.HasPrefix(word, term):
+		return searchPrefix
+	}
+
+	distance := 0
+	switch length := len([]rune(term)); {
+	case length >= 8:
+		distance = 2
+	case length >= 4:
+		distance = 1
+	}
+	if distance > 0 && editDistance(term, word, distance) <= distance {
+		return searchFuzzy
+	}
+
+	return 0
+}
+
+//editDistance returns the Levenshtein distance between a and b, or limit+1 if it is more than limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra)-len(rb) > limit || len(rb)-len(ra) > limit {
+		return limit + 1
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		lowest := current[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			lowest = min(lowest, current[j])
+		}
+		if lowest > limit {
+			return limit + 1
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+//searchWords returns the lower case words in text, words are letters and digits separated by anything else.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
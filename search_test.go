@@ -0,0 +1,185 @@
+package tables
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testSearchLibrary(t *testing.T) *Library {
+	dungeon, err := Load([][]string{{"D4", "Monster"}, {"1-2", "Goblin"}, {"3", "Mimic"}, {"4", "Gelatinous Cube"}}, "dungeon-monsters", "Dungeon Monsters", "d4")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	dungeon.Meta.Campaign = "undermountain"
+	dungeon.Meta.FlavorText = "Things that lurk below."
+
+	chests, err := Load([][]string{{"D2", "Chest"}, {"1", "Gold"}, {"2", "A mimic, roll on dungeon-monsters"}}, "mimics", "Mimic Chests", "d2")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	chests.Meta.Title = "Is it a chest?"
+
+	weather, err := Load([][]string{{"D6", "Weather"}, {"1-3", "Sunny"}, {"4-6", "Rain"}}, "weather", "Weather", "d6")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	return NewLibrary(dungeon, chests, weather)
+}
+
+func TestLibrary_Search(t *testing.T) {
+	library := testSearchLibrary(t)
+
+	tests := []struct {
+		name    string
+		query   string
+		options SearchOptions
+		want    []string
+	}{
+		{"validate names rank higher than rows", "mimic", SearchOptions{}, []string{"mimics", "dungeon-monsters"}},
+		{"validate prefixes match", "gelat", SearchOptions{}, []string{"dungeon-monsters"}},
+		{"validate typos match", "gelatinus", SearchOptions{}, []string{"dungeon-monsters"}},
+		{"validate every word must match", "mimic gold", SearchOptions{}, []string{"mimics"}},
+		{"validate headers and flavor text match", "lurk monster", SearchOptions{}, []string{"dungeon-monsters"}},
+		{"validate case and punctuation are ignored", "IS IT A CHEST?", SearchOptions{}, []string{"mimics"}},
+		{"validate results are filtered by campaign", "mimic", SearchOptions{Campaigns: []string{"undermountain"}}, []string{"dungeon-monsters"}},
+		{"validate results are limited", "mimic", SearchOptions{Limit: 1}, []string{"mimics"}},
+		{"validate short words are not fuzzy", "rai", SearchOptions{}, []string{"weather"}},
+		{"validate nothing matches", "dragon", SearchOptions{}, nil},
+		{"validate an empty query matches nothing", " ", SearchOptions{}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, result := range library.Search(test.query, test.options) {
+				got = append(got, result.Name)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	t.Run("validate results include the fields and rows that matched", func(t *testing.T) {
+		got := library.Search("mimic", SearchOptions{})
+		want := []SearchResult{
+			{Name: "mimics", Score: 8, Fields: []SearchField{SearchName, SearchDisplayName, SearchRows}, Rows: []int{1}},
+			{Name: "dungeon-monsters", Campaign: "undermountain", Score: 1, Fields: []SearchField{SearchRows}, Rows: []int{1}},
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate the index is updated as tables change", func(t *testing.T) {
+		library := testSearchLibrary(t)
+		snapshot := library.Snapshot()
+
+		library.Remove("mimics")
+		err := library.Update("weather", func(table Table) (Table, error) {
+			err := table.UpdateCell(1, 1, "Mimic storm")
+			return table, err
+		})
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		owlbears, _ := Load([][]string{{"Owlbear"}, {"Hug"}}, "owlbears", "Owlbears", "")
+		library.Add(owlbears)
+
+		var got []string
+		for _, result := range library.Search("mimic", SearchOptions{}) {
+			got = append(got, result.Name)
+		}
+		if want := []string{"dungeon-monsters", "weather"}; !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+		if got := library.Search("owlbear", SearchOptions{}); len(got) != 1 {
+			t.Errorf("want owlbears, got %v", got)
+		}
+		if got := snapshot.Search("mimic", SearchOptions{}); len(got) != 2 || got[0].Name != "mimics" {
+			t.Errorf("want the snapshot to be unchanged, got %v", got)
+		}
+	})
+}
+
+func TestLibrary_Search_audience(t *testing.T) {
+	library := NewLibrary(testTrapsTable(t), testDeepTrapsTable(t))
+
+	tests := []struct {
+		name    string
+		query   string
+		options SearchOptions
+		want    []string
+	}{
+		{"validate children are searched with their inherited rows", "rune", SearchOptions{}, []string{"deep_traps", "traps"}},
+		{"validate gm columns are searched for the gm", "dc 18", SearchOptions{Audience: AudienceGM}, []string{"deep_traps", "traps"}},
+		{"validate every column is searched without an audience", "18", SearchOptions{}, []string{"deep_traps", "traps"}},
+		{"validate gm columns are not searched for players", "18", SearchOptions{Audience: AudiencePlayer}, nil},
+		{"validate gm headers are not searched for players", "dc", SearchOptions{Audience: AudiencePlayer}, nil},
+		{"validate other columns are searched for players", "needles sleep", SearchOptions{Audience: AudiencePlayer}, []string{"deep_traps"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, result := range library.Search(test.query, test.options) {
+				got = append(got, result.Name)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	t.Run("validate children are indexed again when their parent changes", func(t *testing.T) {
+		library := NewLibrary(testTrapsTable(t), testDeepTrapsTable(t))
+		err := library.Update("traps", func(table Table) (Table, error) {
+			err := table.UpdateCell(2, 1, "Glyph")
+			return table, err
+		})
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		var got []string
+		for _, result := range library.Search("glyph", SearchOptions{}) {
+			got = append(got, result.Name)
+		}
+		if want := []string{"deep_traps", "traps"}; !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+
+		library.Remove("traps")
+		if got := library.Search("needles", SearchOptions{}); len(got) != 0 {
+			t.Errorf("want no rows of a child without its parent, got %v", got)
+		}
+		if got := library.Search("deep traps", SearchOptions{}); len(got) != 1 {
+			t.Errorf("want the child's meta data, got %v", got)
+		}
+	})
+}
+
+func Test_editDistance(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"mimic", "mimic", 1, 0},
+		{"mimik", "mimic", 1, 1},
+		{"mimc", "mimic", 1, 1},
+		{"gelatinus", "gelatinous", 2, 1},
+		{"goblin", "mimic", 2, 3},
+		{"ogre", "ogres", 0, 1},
+	}
+
+	for _, test := range tests {
+		t.Run("validate "+test.a+" and "+test.b, func(t *testing.T) {
+			got := editDistance(test.a, test.b, test.limit)
+			if test.want != got {
+				t.Errorf("want %d, got %d", test.want, got)
+			}
+		})
+	}
+}
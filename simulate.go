@@ -0,0 +1,345 @@
+package tables
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const ErrReferenceDepth = TableError("table references are too deep, a table may reference itself")
+
+//MaxReferenceDepth is the most tables that can be rolled within each other by table columns while simulating, it
+//stops tables that always reference themselves.
+const MaxReferenceDepth = 32
+
+//SimulationPercentiles are the percentiles written for numeric columns by Simulation.Report.
+var SimulationPercentiles = []float64{5, 25, 50, 75, 95}
+
+var leadingNumberRE = regexp.MustCompile(`^[+-]?\d[\d,]*(\.\d+)?`)
+
+//Simulation summarizes the results of running a table expression or template many times.
+type Simulation struct {
+	//Runs is the number of times the table expression or template was run.
+	Runs    int
+	Columns []SimulationColumn
+}
+
+//SimulationColumn summarizes the values of one result column across every run.
+type SimulationColumn struct {
+	Name string
+	Type ColumnType
+	//Numeric is true when the values of the column start with a number (e.g. 12, -3, or 1,200 gp). Values without
+	//any digits (e.g. Nothing) count as 0, any other value makes the column categorical.
+	Numeric bool
+	//Totals holds the sum of the column's numbers for each run, sorted from lowest to highest. It is only set for
+	//numeric columns.
+	Totals []float64
+	//Frequencies counts how many times each value was rolled.
+	Frequencies map[string]int
+}
+
+//Simulate runs the table expression n times using r and summarizes every result column except the roll column.
+//Int, decimal, currency, and value columns (see ColumnType) are always numeric, values are totaled in the base unit
+//of their unit system. Currencies can't be converted, so ErrMixedCurrencies is returned if the values of a currency
+//column are not all in the same unit. The table is resolved once, so changes to the library while simulating are not
+//seen. A nil r uses the dice package. Cells of table columns (see ColumnTable) are rolled on the tables they reference,
+//and nested tables are rolled in turn, each rolled row is summarized as its result columns joined with a comma.
+//ErrReferenceDepth is returned if tables are rolled more than MaxReferenceDepth deep.
+func (l *Library) Simulate(r Rand, te string, n int) (Simulation, error) {
+	te = strings.TrimSpace(te)
+	name := ParseTablename(te)
+	if name == "" {
+		return Simulation{}, ErrInvalidTableExpression
+	}
+
+	library := l.Snapshot()
+	table, err := library.Resolve(name)
+	if err != nil {
+		return Simulation{}, err
+	}
+
+	headers := make([]string, 0, len(table.Meta.Headers))
+	types := make([]ColumnType, 0, len(table.Meta.Headers))
+	for i := 1; i < len(table.Meta.Headers); i++ {
+		headers = append(headers, table.Meta.Headers[i])
+		types = append(types, table.ColumnType(i))
+	}
+
+	return simulate(n, headers, types, func() ([][]string, error) {
+		records, err := table.ExpressionWith(r, te)
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([][]string, len(headers))
+		for _, record := range records[1:] {
+			for i, value := range record[1:] {
+				if i >= len(results) {
+					break
+				}
+				if types[i].Kind() != ColumnTable {
+					results[i] = append(results[i], value)
+					continue
+				}
+
+				values, err := library.rollReference(r, value, 1)
+				if err != nil {
+					return nil, err
+				}
+				results[i] = append(results[i], values...)
+			}
+		}
+		return results, nil
+	})
+}
+
+//rollReference rolls the table expression in the cell of a table column (see Value.Reference) and returns a value for
+//each rolled row, its result columns joined with a comma. Table columns of the rolled rows are rolled in turn, depth is
+//the number of tables rolled within each other so far.
+func (l *Library) rollReference(r Rand, cell string, depth int) ([]string, error) {
+	if depth > MaxReferenceDepth {
+		return nil, ErrReferenceDepth
+	}
+
+	te, err := Value{Type: ColumnTable, Text: cell}.Reference()
+	if err != nil {
+		return nil, err
+	}
+	table, err := l.Resolve(ParseTablename(te))
+	if err != nil {
+		return nil, err
+	}
+	records, err := table.ExpressionWith(r, te)
+	if err != nil {
+		return nil, err
+	}
+
+	first := 0
+	if table.Meta.RollableTable {
+		first = 1
+	}
+
+	var values []string
+	for _, record := range records[1:] {
+		var results []string
+		for i := first; i < len(record); i++ {
+			if table.ColumnType(i).Kind() != ColumnTable {
+				results = append(results, record[i])
+				continue
+			}
+
+			nested, err := l.rollReference(r, record[i], depth+1)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, nested...)
+		}
+		values = append(values, strings.Join(results, ", "))
+	}
+
+	return values, nil
+}
+
+//Simulate executes the template n times using r and summarizes its output as a single Result column. The library is
+//snapshot once, so changes to it while simulating are not seen. A nil r uses the dice package.
+func (t *Template) Simulate(library *Library, r Rand, n int) (Simulation, error) {
+	library = library.Snapshot()
+
+	return simulate(n, []string{"Result"}, []ColumnType{ColumnText}, func() ([][]string, error) {
+		output, err := t.Execute(library, r)
+		if err != nil {
+			return nil, err
+		}
+		return [][]string{{output}}, nil
+	})
+}
+
+//Sum returns the sum of every run's total.
+func (c SimulationColumn) Sum() float64 {
+	sum := 0.0
+	for _, total := range c.Totals {
+		sum += total
+	}
+
+	return sum
+}
+
+//Mean returns the mean total of a run.
+func (c SimulationColumn) Mean() float64 {
+	if len(c.Totals) == 0 {
+		return 0
+	}
+
+	return c.Sum() / float64(len(c.Totals))
+}
+
+//Percentile returns the run total below which p percent of the totals fall, interpolating between the nearest totals.
+func (c SimulationColumn) Percentile(p float64) float64 {
+	if len(c.Totals) == 0 {
+		return 0
+	}
+
+	rank := math.Max(0, math.Min(p, 100)) / 100 * float64(len(c.Totals)-1)
+	low := int(math.Floor(rank))
+	high := int(math.Ceil(rank))
+
+	return c.Totals[low] + (c.Totals[high]-c.Totals[low])*(rank-float64(low))
+}
+
+//Report writes a summary of the simulation, the sum, mean, and percentiles of numeric columns and the frequency of
+//each value of categorical columns (most frequent first). Value columns are written in their preferred unit.
+func (s Simulation) Report(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%d runs\n", s.Runs)
+
+	for _, column := range s.Columns {
+		fmt.Fprintf(tw, "\n%s\n", column.Name)
+		if column.Numeric {
+			format := formatNumber
+			if system, err := column.Type.UnitSystem(); err == nil {
+				format = system.Format
+			}
+
+			fmt.Fprintf(tw, "sum\t%s\n", format(column.Sum()))
+			fmt.Fprintf(tw, "mean\t%s\n", format(column.Mean()))
+			fmt.Fprintf(tw, "min\t%s\n", format(column.Totals[0]))
+			for _, p := range SimulationPercentiles {
+				fmt.Fprintf(tw, "p%s\t%s\n", formatNumber(p), format(column.Percentile(p)))
+			}
+			fmt.Fprintf(tw, "max\t%s\n", format(column.Totals[len(column.Totals)-1]))
+			continue
+		}
+
+		values := make([]string, 0, len(column.Frequencies))
+		total := 0
+		for value, count := range column.Frequencies {
+			values = append(values, value)
+			total += count
+		}
+		sort.Slice(values, func(i, j int) bool {
+			a, b := column.Frequencies[values[i]], column.Frequencies[values[j]]
+			if a != b {
+				return a > b
+			}
+			return values[i] < values[j]
+		})
+		for _, value := range values {
+			count := column.Frequencies[value]
+			fmt.Fprintf(tw, "%s\t%d\t%.2f%%\n", value, count, float64(count)/float64(total)*100)
+		}
+	}
+
+	return tw.Flush()
+}
+
+//simulate calls run n times and summarizes the values it returns for each header, in the same order as headers.
+func simulate(n int, headers []string, types []ColumnType, run func() ([][]string, error)) (Simulation, error) {
+	if n < 1 {
+		return Simulation{}, ErrInvalidRollCount
+	}
+
+	columns := make([]SimulationColumn, len(headers))
+	numbers := make([]bool, len(headers))
+	//units holds the unit of each currency column's first value, every value must have the same unit
+	units := make(map[int]string)
+	for i, header := range headers {
+		columns[i] = SimulationColumn{Name: header, Type: types[i], Numeric: true, Totals: make([]float64, 0, n), Frequencies: make(map[string]int)}
+	}
+
+	for runs := 0; runs < n; runs++ {
+		results, err := run()
+		if err != nil {
+			return Simulation{}, err
+		}
+
+		totals := make([]float64, len(columns))
+		for i, values := range results {
+			if i >= len(columns) {
+				break
+			}
+			for _, value := range values {
+				columns[i].Frequencies[value]++
+				if !columns[i].Numeric {
+					continue
+				}
+
+				if types[i].Kind() == ColumnCurrency {
+					if _, unit, err := (Value{Type: types[i], Text: value}).Currency(); err == nil {
+						if previous, ok := units[i]; ok && previous != unit {
+							return Simulation{}, ErrMixedCurrencies
+						}
+						units[i] = unit
+					}
+				}
+
+				number, ok, typed := parseTyped(types[i], value)
+				if !ok {
+					columns[i].Numeric = false
+					continue
+				}
+				if typed || strings.ContainsAny(value, "0123456789") {
+					numbers[i] = true
+				}
+				totals[i] += number
+			}
+		}
+
+		for i := range columns {
+			if columns[i].Numeric {
+				columns[i].Totals = append(columns[i].Totals, totals[i])
+			}
+		}
+	}
+
+	for i := range columns {
+		//a column of values without numbers (e.g. only Nothing) is not numeric
+		columns[i].Numeric = columns[i].Numeric && numbers[i]
+		if !columns[i].Numeric {
+			columns[i].Totals = nil
+			continue
+		}
+		sort.Float64s(columns[i].Totals)
+	}
+
+	return Simulation{Runs: n, Columns: columns}, nil
+}
+
+//parseTyped returns the number in a value of a column with the provided type, using parseNumber for columns that are
+//not numeric types. The last result is true if the column has a numeric type.
+func parseTyped(columnType ColumnType, value string) (float64, bool, bool) {
+	switch columnType.Kind() {
+	case ColumnInt, ColumnDecimal, ColumnCurrency, ColumnValue:
+		number, err := Value{Type: columnType, Text: value}.Decimal()
+		return number, err == nil, true
+	}
+
+	number, ok := parseNumber(value)
+	return number, ok, false
+}
+
+//parseNumber returns the number a value starts with, a value without any digits is 0. It returns false for a value
+//that has digits but does not start with a number.
+func parseNumber(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	match := leadingNumberRE.FindString(value)
+	if match == "" {
+		return 0, !strings.ContainsAny(value, "0123456789")
+	}
+
+	number, err := strconv.ParseFloat(strings.ReplaceAll(match, ",", ""), 64)
+	return number, err == nil
+}
+
+//formatNumber formats whole numbers without decimals and everything else with two.
+func formatNumber(number float64) string {
+	if number == math.Trunc(number) && math.Abs(number) < 1e15 {
+		return strconv.FormatFloat(number, 'f', 0, 64)
+	}
+
+	return strconv.FormatFloat(number, 'f', 2, 64)
+}
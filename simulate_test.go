@@ -0,0 +1,223 @@
+package tables
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var treasureCSV = [][]string{
+	{"D2", "Treasure", "Guard"},
+	{"1", "{{10d1}} gp", "Goblin"},
+	{"2", "Nothing", "Wolf"},
+}
+
+func testSimulationLibrary(t *testing.T) *Library {
+	treasure, err := Load(treasureCSV, "treasure", "Treasure", "d2")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	coins, err := Load([][]string{{"D1", "Coins"}, {"1", "1,000"}}, "coins", "Coins", "d1")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	return NewLibrary(treasure, coins)
+}
+
+func TestLibrary_Simulate(t *testing.T) {
+	library := testSimulationLibrary(t)
+
+	t.Run("validate numeric columns are totaled for each run", func(t *testing.T) {
+		got, err := library.Simulate(NewRand(1), "3?treasure", 200)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		if got.Runs != 200 || len(got.Columns) != 2 {
+			t.Fatalf("want 200 runs of 2 columns, got %d runs of %d columns", got.Runs, len(got.Columns))
+		}
+		treasure := got.Columns[0]
+		if treasure.Name != "Treasure" || !treasure.Numeric || len(treasure.Totals) != 200 {
+			t.Fatalf("want 200 numeric Treasure totals, got %v", treasure)
+		}
+		if treasure.Totals[0] < 0 || treasure.Totals[199] > 30 {
+			t.Errorf("want totals from 0 to 30, got %v to %v", treasure.Totals[0], treasure.Totals[199])
+		}
+		if got := treasure.Frequencies["10 gp"] + treasure.Frequencies["Nothing"]; got != 600 {
+			t.Errorf("want 600, got %d", got)
+		}
+		if treasure.Sum() != float64(treasure.Frequencies["10 gp"]*10) {
+			t.Errorf("want %d, got %v", treasure.Frequencies["10 gp"]*10, treasure.Sum())
+		}
+		if mean := treasure.Mean(); mean < 12 || mean > 18 {
+			t.Errorf("want a mean near 15, got %v", mean)
+		}
+	})
+
+	t.Run("validate columns of words are categorical", func(t *testing.T) {
+		got, err := library.Simulate(NewRand(1), "1#treasure", 10)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		guard := got.Columns[1]
+		if guard.Numeric || guard.Totals != nil {
+			t.Errorf("want a categorical column, got %v", guard)
+		}
+		if want := map[string]int{"Goblin": 10}; !reflect.DeepEqual(want, guard.Frequencies) {
+			t.Errorf("want %v, got %v", want, guard.Frequencies)
+		}
+	})
+
+	t.Run("validate a report is written", func(t *testing.T) {
+		simulation, err := library.Simulate(nil, "2?coins", 4)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		var b bytes.Buffer
+		err = simulation.Report(&b)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := "4 runs\n\nCoins\nsum   8000\nmean  2000\nmin   2000\np5    2000\np25   2000\np50   2000\np75   2000\np95   2000\nmax   2000\n"
+		if b.String() != want {
+			t.Errorf("want %q, got %q", want, b.String())
+		}
+	})
+
+	testErrors := []struct {
+		name       string
+		expression string
+		runs       int
+		want       error
+	}{
+		{name: "validate an error is returned for no runs", expression: "2?treasure", runs: 0, want: ErrInvalidRollCount},
+		{name: "validate an error is returned for a table that is not in the library", expression: "2?nope", runs: 1, want: ErrTableDoesNotExist},
+		{name: "validate an error is returned for an invalid table expression", expression: "2", runs: 1, want: ErrInvalidTableExpression},
+		{name: "validate an error is returned for a roll that is not in the table", expression: "3#treasure", runs: 1, want: ErrInvalidTableRollValue},
+	}
+
+	t.Run("validate tables referenced by table columns are rolled", func(t *testing.T) {
+		hoard, _ := Load([][]string{{"D2", "Loot:table", "Guard"}, {"1", "2?gold", "Dragon"}, {"2", "gems", "Wolf"}}, "hoard", "Hoard", "d2")
+		gold, _ := Load([][]string{{"D1", "Gold"}, {"1", "{{1d1}}0 gp"}}, "gold", "Gold", "d1")
+		gems, _ := Load([][]string{{"D1", "Gem", "Extra:table"}, {"1", "Ruby", "gold"}}, "gems", "Gems", "d1")
+
+		got, err := NewLibrary(hoard, gold, gems).Simulate(NewRand(1), "1#hoard", 10)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		loot := got.Columns[0]
+		if !loot.Numeric || loot.Sum() != 200 || loot.Frequencies["10 gp"] != 20 {
+			t.Errorf("want 2 rows of 10 gp each run, got %v", loot)
+		}
+
+		got, err = NewLibrary(hoard, gold, gems).Simulate(NewRand(1), "2#hoard", 10)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if want := map[string]int{"Ruby, 10 gp": 10}; !reflect.DeepEqual(want, got.Columns[0].Frequencies) {
+			t.Errorf("want %v, got %v", want, got.Columns[0].Frequencies)
+		}
+	})
+
+	t.Run("validate an error is returned for tables that reference themselves", func(t *testing.T) {
+		loop, _ := Load([][]string{{"D1", "Again:table"}, {"1", "loop"}}, "loop", "Loop", "d1")
+
+		_, err := NewLibrary(loop).Simulate(NewRand(1), "?loop", 1)
+		if err != ErrReferenceDepth {
+			t.Errorf("want %v, got %v", ErrReferenceDepth, err)
+		}
+	})
+
+	t.Run("validate an error is returned for currencies in different units", func(t *testing.T) {
+		loot, err := Load([][]string{{"D2", "Loot:currency"}, {"1", "2 gp"}, {"2", "50 cp"}}, "loot", "Loot", "d2")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		_, err = NewLibrary(loot).Simulate(NewRand(1), "2?loot", 100)
+		if err != ErrMixedCurrencies {
+			t.Errorf("want %v, got %v", ErrMixedCurrencies, err)
+		}
+	})
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := library.Simulate(nil, test.expression, test.runs)
+			if !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestTemplate_Simulate(t *testing.T) {
+	template, err := ParseTemplate("{?treasure[Guard]}")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	got, err := template.Simulate(testSimulationLibrary(t), NewRand(1), 100)
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	result := got.Columns[0]
+	if result.Name != "Result" || result.Numeric {
+		t.Errorf("want a categorical Result column, got %v", result)
+	}
+	if result.Frequencies["Goblin"]+result.Frequencies["Wolf"] != 100 || result.Frequencies["Goblin"] == 0 || result.Frequencies["Wolf"] == 0 {
+		t.Errorf("want goblins and wolves, got %v", result.Frequencies)
+	}
+}
+
+func TestSimulationColumn_Percentile(t *testing.T) {
+	column := SimulationColumn{Numeric: true, Totals: []float64{1, 2, 3, 4, 5}}
+
+	tests := []struct {
+		percentile float64
+		want       float64
+	}{
+		{percentile: 0, want: 1},
+		{percentile: 50, want: 3},
+		{percentile: 90, want: 4.6},
+		{percentile: 100, want: 5},
+		{percentile: 150, want: 5},
+	}
+
+	for _, test := range tests {
+		t.Run("validate percentile "+formatNumber(test.percentile), func(t *testing.T) {
+			got := column.Percentile(test.percentile)
+			if !almostEqual(test.want, got) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_parseNumber(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		numeric bool
+	}{
+		{value: "12", want: 12, numeric: true},
+		{value: "-3.5 hp", want: -3.5, numeric: true},
+		{value: "1,200 gp", want: 1200, numeric: true},
+		{value: "Nothing", want: 0, numeric: true},
+		{value: "Sword +1", want: 0, numeric: false},
+	}
+
+	for _, test := range tests {
+		t.Run("validate number for "+test.value, func(t *testing.T) {
+			got, numeric := parseNumber(test.value)
+			if got != test.want || numeric != test.numeric {
+				t.Errorf("want %v (%t), got %v (%t)", test.want, test.numeric, got, numeric)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+//Package sqlitestore provides a tables.Store backed by an embedded SQLite database.
+package sqlitestore
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/fantastical-world/tables"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS tables (
+	campaign TEXT NOT NULL,
+	name     TEXT NOT NULL,
+	data     BLOB NOT NULL,
+	PRIMARY KEY (campaign, name)
+)`
+
+//Store is a tables.Store that keeps packed tables in a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+//Open opens (creating if needed) the SQLite database at path and returns a Store using it.
+//Use ":memory:" for a database that only lives as long as the Store.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	//sqlite only allows a single writer, and each connection to ":memory:" is a separate database
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(schema)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+//Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) Get(campaign, name string) (tables.Table, error) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT data FROM tables WHERE campaign = ? AND name = ?`, campaign, name).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return tables.Table{}, tables.ErrTableDoesNotExist
+	}
+	if err != nil {
+		return tables.Table{}, err
+	}
+
+	table := tables.Table{}
+	table.Unpack(data)
+	if table.Meta.Name == "" {
+		return tables.Table{}, tables.ErrTableInvalid
+	}
+
+	return table, nil
+}
+
+func (s *Store) Put(table tables.Table) error {
+	if table.Meta.Name == "" {
+		return tables.ErrTableInvalid
+	}
+
+	name, data := table.Pack()
+	_, err := s.db.Exec(`INSERT INTO tables (campaign, name, data) VALUES (?, ?, ?)
+		ON CONFLICT (campaign, name) DO UPDATE SET data = excluded.data`, table.Meta.Campaign, name, data)
+
+	return err
+}
+
+func (s *Store) Delete(campaign, name string) error {
+	result, err := s.db.Exec(`DELETE FROM tables WHERE campaign = ? AND name = ?`, campaign, name)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return tables.ErrTableDoesNotExist
+	}
+
+	return nil
+}
+
+func (s *Store) List(campaign string) ([]string, error) {
+	rows, err := s.db.Query(`SELECT name FROM tables WHERE campaign = ? ORDER BY name`, campaign)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
@@ -0,0 +1,104 @@
+package sqlitestore
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/fantastical-world/tables"
+)
+
+var _ tables.Store = (*Store)(nil)
+
+func TestStore(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "tables.db"))
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	defer store.Close()
+
+	table, err := tables.Load([][]string{{"D4", "Result"}, {"1-2", "Low"}, {"3-4", "High {{1d4}}"}}, "test", "Test", "d4")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	table.Meta.Campaign = "campaign"
+
+	t.Run("validate an error is returned when getting a table that is not stored", func(t *testing.T) {
+		_, err := store.Get("campaign", "test")
+		if !errors.Is(err, tables.ErrTableDoesNotExist) {
+			t.Errorf("want %s, got %v", tables.ErrTableDoesNotExist, err)
+		}
+	})
+
+	t.Run("validate a stored table can be retrieved", func(t *testing.T) {
+		err := store.Put(table)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		got, err := store.Get("campaign", "test")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if !reflect.DeepEqual(table, got) {
+			t.Errorf("want %v, got %v", table, got)
+		}
+	})
+
+	t.Run("validate a stored table is replaced", func(t *testing.T) {
+		replaced := table
+		replaced.Meta.DisplayName = "Replaced"
+		err := store.Put(replaced)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		got, err := store.Get("campaign", "test")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got.Meta.DisplayName != "Replaced" {
+			t.Errorf("want Replaced, got %s", got.Meta.DisplayName)
+		}
+	})
+
+	t.Run("validate stored tables are listed by campaign", func(t *testing.T) {
+		err := store.Put(tables.Table{Meta: tables.Meta{Name: "another", Campaign: "campaign"}})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		err = store.Put(tables.Table{Meta: tables.Meta{Name: "elsewhere", Campaign: "other-campaign"}})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		got, err := store.List("campaign")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		want := []string{"another", "test"}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate a stored table can be deleted", func(t *testing.T) {
+		err := store.Delete("campaign", "test")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		err = store.Delete("campaign", "test")
+		if !errors.Is(err, tables.ErrTableDoesNotExist) {
+			t.Errorf("want %s, got %v", tables.ErrTableDoesNotExist, err)
+		}
+	})
+
+	t.Run("validate an error is returned when storing a table without a name", func(t *testing.T) {
+		err := store.Put(tables.Table{})
+		if !errors.Is(err, tables.ErrTableInvalid) {
+			t.Errorf("want %s, got %v", tables.ErrTableInvalid, err)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package tables
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const ErrInvalidStoreKey = TableError("campaign or table name can not be used as a store key")
+
+//Store persists tables using their campaign and name as the key. Tables are stored in their packed form (see Pack).
+type Store interface {
+	//Get returns the table with the provided name in campaign, ErrTableDoesNotExist is returned if it is not stored.
+	Get(campaign, name string) (Table, error)
+	//Put stores the table using its Meta.Campaign and Meta.Name, replacing any table already stored with that key.
+	Put(table Table) error
+	//Delete removes the table with the provided name in campaign, ErrTableDoesNotExist is returned if it is not stored.
+	Delete(campaign, name string) error
+	//List returns the sorted names of all tables stored for campaign.
+	List(campaign string) ([]string, error)
+}
+
+//MemoryStore is an in-memory Store, it is safe for concurrent use.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	campaigns map[string]map[string][]byte
+}
+
+//NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{campaigns: make(map[string]map[string][]byte)}
+}
+
+func (s *MemoryStore) Get(campaign, name string) (Table, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.campaigns[campaign][name]
+	if !ok {
+		return Table{}, ErrTableDoesNotExist
+	}
+
+	return unpackStored(data)
+}
+
+func (s *MemoryStore) Put(table Table) error {
+	if table.Meta.Name == "" {
+		return ErrTableInvalid
+	}
+
+	name, data := table.Pack()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.campaigns[table.Meta.Campaign] == nil {
+		s.campaigns[table.Meta.Campaign] = make(map[string][]byte)
+	}
+	s.campaigns[table.Meta.Campaign][name] = data
+
+	return nil
+}
+
+func (s *MemoryStore) Delete(campaign, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.campaigns[campaign][name]; !ok {
+		return ErrTableDoesNotExist
+	}
+	delete(s.campaigns[campaign], name)
+	if len(s.campaigns[campaign]) == 0 {
+		delete(s.campaigns, campaign)
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) List(campaign string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var names []string
+	for name := range s.campaigns[campaign] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+//FileStore is a Store that keeps each table as a JSON file on disk.
+//Tables are written to dir/campaign/name.json, tables without a campaign are written to dir/name.json.
+type FileStore struct {
+	dir string
+}
+
+//NewFileStore returns a FileStore rooted at dir, the directory is created when the first table is stored.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) Get(campaign, name string) (Table, error) {
+	path, err := s.path(campaign, name)
+	if err != nil {
+		return Table{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Table{}, ErrTableDoesNotExist
+	}
+	if err != nil {
+		return Table{}, err
+	}
+
+	return unpackStored(data)
+}
+
+func (s *FileStore) Put(table Table) error {
+	if table.Meta.Name == "" {
+		return ErrTableInvalid
+	}
+
+	path, err := s.path(table.Meta.Campaign, table.Meta.Name)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	//write to a temporary file first so readers never see a partially written table
+	_, data := table.Pack()
+	temp, err := os.CreateTemp(filepath.Dir(path), ".table-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	_, err = temp.Write(data)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
+
+func (s *FileStore) Delete(campaign, name string) error {
+	path, err := s.path(campaign, name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrTableDoesNotExist
+	}
+
+	return err
+}
+
+func (s *FileStore) List(campaign string) ([]string, error) {
+	dir := s.dir
+	if campaign != "" {
+		if !validStoreKey(campaign) {
+			return nil, ErrInvalidStoreKey
+		}
+		dir = filepath.Join(s.dir, campaign)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (s *FileStore) path(campaign, name string) (string, error) {
+	if !validStoreKey(name) {
+		return "", ErrInvalidStoreKey
+	}
+
+	if campaign == "" {
+		return filepath.Join(s.dir, name+".json"), nil
+	}
+
+	if !validStoreKey(campaign) {
+		return "", ErrInvalidStoreKey
+	}
+
+	return filepath.Join(s.dir, campaign, name+".json"), nil
+}
+
+//validStoreKey returns true if value can safely be used as a single path element.
+func validStoreKey(value string) bool {
+	if value == "" || value == "." || value == ".." || strings.HasPrefix(value, ".") {
+		return false
+	}
+
+	return !strings.ContainsAny(value, `/\`)
+}
+
+//unpackStored unpacks data previously written by Pack, returning ErrTableInvalid if it is not a packed table.
+func unpackStored(data []byte) (Table, error) {
+	table := Table{}
+	table.Unpack(data)
+	if table.Meta.Name == "" {
+		return Table{}, ErrTableInvalid
+	}
+
+	return table, nil
+}
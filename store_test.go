@@ -0,0 +1,166 @@
+package tables
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testStore(t *testing.T, store Store) {
+	table, err := Load(testCSV, "test", "Test", "d6")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	table.Meta.Campaign = "campaign"
+
+	other, err := Load(rangedCSV, "ranged", "Ranged", "d6")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	other.Meta.Campaign = "campaign"
+
+	t.Run("validate an error is returned when getting a table that is not stored", func(t *testing.T) {
+		_, err := store.Get("campaign", "test")
+		if !errors.Is(err, ErrTableDoesNotExist) {
+			t.Errorf("want %s, got %v", ErrTableDoesNotExist, err)
+		}
+	})
+
+	t.Run("validate a stored table can be retrieved", func(t *testing.T) {
+		err := store.Put(table)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		got, err := store.Get("campaign", "test")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if !reflect.DeepEqual(table, got) {
+			t.Errorf("want %v, got %v", table, got)
+		}
+	})
+
+	t.Run("validate tables are stored by campaign", func(t *testing.T) {
+		_, err := store.Get("other-campaign", "test")
+		if !errors.Is(err, ErrTableDoesNotExist) {
+			t.Errorf("want %s, got %v", ErrTableDoesNotExist, err)
+		}
+	})
+
+	t.Run("validate a stored table is replaced", func(t *testing.T) {
+		replaced := table
+		replaced.Meta.DisplayName = "Replaced"
+		err := store.Put(replaced)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		got, err := store.Get("campaign", "test")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got.Meta.DisplayName != "Replaced" {
+			t.Errorf("want Replaced, got %s", got.Meta.DisplayName)
+		}
+	})
+
+	t.Run("validate stored tables are listed by campaign", func(t *testing.T) {
+		err := store.Put(other)
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		got, err := store.List("campaign")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		want := []string{"ranged", "test"}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+
+		got, err = store.List("other-campaign")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("want 0, got %d", len(got))
+		}
+	})
+
+	t.Run("validate a stored table can be deleted", func(t *testing.T) {
+		err := store.Delete("campaign", "test")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		_, err = store.Get("campaign", "test")
+		if !errors.Is(err, ErrTableDoesNotExist) {
+			t.Errorf("want %s, got %v", ErrTableDoesNotExist, err)
+		}
+	})
+
+	t.Run("validate an error is returned when deleting a table that is not stored", func(t *testing.T) {
+		err := store.Delete("campaign", "test")
+		if !errors.Is(err, ErrTableDoesNotExist) {
+			t.Errorf("want %s, got %v", ErrTableDoesNotExist, err)
+		}
+	})
+
+	t.Run("validate an error is returned when storing a table without a name", func(t *testing.T) {
+		err := store.Put(Table{})
+		if !errors.Is(err, ErrTableInvalid) {
+			t.Errorf("want %s, got %v", ErrTableInvalid, err)
+		}
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	testStore(t, NewFileStore(dir))
+
+	t.Run("validate tables without a campaign are stored in the root directory", func(t *testing.T) {
+		store := NewFileStore(dir)
+		err := store.Put(Table{Meta: Meta{Name: "loose"}})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		_, err = os.Stat(filepath.Join(dir, "loose.json"))
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+	})
+
+	t.Run("validate an error is returned for keys that are not a single path element", func(t *testing.T) {
+		store := NewFileStore(dir)
+		err := store.Put(Table{Meta: Meta{Name: "escape", Campaign: "../outside"}})
+		if !errors.Is(err, ErrInvalidStoreKey) {
+			t.Errorf("want %s, got %v", ErrInvalidStoreKey, err)
+		}
+
+		_, err = store.Get("..", "escape")
+		if !errors.Is(err, ErrInvalidStoreKey) {
+			t.Errorf("want %s, got %v", ErrInvalidStoreKey, err)
+		}
+	})
+
+	t.Run("validate an error is returned for a file that is not a packed table", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("not json"), 0o644)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		_, err = NewFileStore(dir).Get("", "broken")
+		if !errors.Is(err, ErrTableInvalid) {
+			t.Errorf("want %s, got %v", ErrTableInvalid, err)
+		}
+	})
+}
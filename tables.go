@@ -4,6 +4,7 @@ import (
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -22,6 +23,15 @@ const ErrTableNotRollable = TableError("not a rollable table, no roll expression
 const ErrInvalidTableExpression = TableError("not a valid table expression")
 const ErrTableDoesNotMatchTableExpression = TableError("table is not the table in the table expression")
 const ErrInvalidRollColumn = TableError("first column must be an integer since it represents a die roll")
+const ErrPatchDoesNotApply = TableError("patch does not apply to table")
+const ErrDuplicateRowKey = TableError("rows have the same key, see RowKey")
+const ErrRowDoesNotExist = TableError("row does not exist")
+const ErrColumnDoesNotExist = TableError("column does not exist")
+const ErrInvalidColumnCount = TableError("row does not have the same number of columns as the table")
+const ErrOverlappingRoll = TableError("roll overlaps the roll of another row")
+const ErrInvalidRowPosition = TableError("row roll does not fit at this position")
+const ErrInvalidRollExpression = TableError("not a valid roll expression")
+const ErrNotEnoughRollValues = TableError("roll expression does not have enough values for every row")
 
 var (
 	TableRollExpressionRE = regexp.MustCompile(`^([0-9]*)([\?|#])([a-zA-Z,0-9,_,\.,\-]+)$`)
@@ -29,29 +39,37 @@ var (
 
 //Table represents a table with meta data and rows
 type Table struct {
-	Meta Meta  `json:"meta"`
-	Rows []Row `json:"rows"`
+	Meta Meta  `json:"meta" yaml:"meta"`
+	Rows []Row `json:"rows" yaml:"rows"`
 }
 
 //Meta stores metadata for a table
 type Meta struct {
-	Name           string   `json:"name"`
-	DisplayName    string   `json:"display_name"`
-	Title          string   `json:"title"`
-	FlavorText     string   `json:"flavor_text"`
-	Campaign       string   `json:"campaign"`
-	Headers        []string `json:"headers"`
-	ColumnCount    int      `json:"column_count"`
-	RollableTable  bool     `json:"rollable_table"`
-	RollExpression string   `json:"roll_expression"`
+	Name           string                `json:"name" yaml:"name"`
+	DisplayName    string                `json:"display_name" yaml:"display_name"`
+	Title          string                `json:"title" yaml:"title"`
+	FlavorText     string                `json:"flavor_text" yaml:"flavor_text"`
+	Campaign       string                `json:"campaign" yaml:"campaign"`
+	Headers        []string              `json:"headers" yaml:"headers"`
+	ColumnTypes    []ColumnType          `json:"column_types,omitempty" yaml:"column_types,omitempty"`
+	Visibility     []Audience            `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+	ColumnCount    int                   `json:"column_count" yaml:"column_count"`
+	RollableTable  bool                  `json:"rollable_table" yaml:"rollable_table"`
+	RollExpression string                `json:"roll_expression" yaml:"roll_expression"`
+	Locales        map[string]MetaLocale `json:"locales,omitempty" yaml:"locales,omitempty"`
+	Parent         string                `json:"parent,omitempty" yaml:"parent,omitempty"`
 }
 
 //Row represents a row from a table
 type Row struct {
-	DieRoll           int      `json:"die_roll"`
-	RollRange         string   `json:"roll_range"`
-	HasRollExpression bool     `json:"has_roll_expression"`
-	Results           []string `json:"results"`
+	DieRoll           int                 `json:"die_roll" yaml:"die_roll"`
+	RollRange         string              `json:"roll_range" yaml:"roll_range"`
+	HasRollExpression bool                `json:"has_roll_expression" yaml:"has_roll_expression"`
+	Results           []string            `json:"results" yaml:"results"`
+	Locales           map[string][]string `json:"locales,omitempty" yaml:"locales,omitempty"`
+	Action            string              `json:"action,omitempty" yaml:"action,omitempty"`
+	//Meta holds meta data about the row that is not one of its results (e.g. source, page, notes), see RowMetaSource.
+	Meta map[string]string `json:"meta,omitempty" yaml:"meta,omitempty"`
 }
 
 func (t Table) Pack() (string, []byte) {
@@ -67,6 +85,49 @@ func (t *Table) Unpack(data []byte) {
 	}
 }
 
+//Clone returns a deep copy of the table, changes to the copy are never seen by the original.
+func (t Table) Clone() Table {
+	clone := Table{Meta: t.Meta}
+	clone.Meta.Headers = cloneStrings(t.Meta.Headers)
+	if t.Meta.ColumnTypes != nil {
+		clone.Meta.ColumnTypes = append(make([]ColumnType, 0, len(t.Meta.ColumnTypes)), t.Meta.ColumnTypes...)
+	}
+	if t.Meta.Visibility != nil {
+		clone.Meta.Visibility = append(make([]Audience, 0, len(t.Meta.Visibility)), t.Meta.Visibility...)
+	}
+	if t.Meta.Locales != nil {
+		clone.Meta.Locales = make(map[string]MetaLocale, len(t.Meta.Locales))
+		for locale, meta := range t.Meta.Locales {
+			meta.Headers = cloneStrings(meta.Headers)
+			clone.Meta.Locales[locale] = meta
+		}
+	}
+
+	if t.Rows != nil {
+		clone.Rows = make([]Row, len(t.Rows))
+	}
+	for i, row := range t.Rows {
+		row.Results = cloneStrings(row.Results)
+		if row.Locales != nil {
+			locales := make(map[string][]string, len(row.Locales))
+			for locale, results := range row.Locales {
+				locales[locale] = cloneStrings(results)
+			}
+			row.Locales = locales
+		}
+		if row.Meta != nil {
+			meta := make(map[string]string, len(row.Meta))
+			for key, value := range row.Meta {
+				meta[key] = value
+			}
+			row.Meta = meta
+		}
+		clone.Rows[i] = row
+	}
+
+	return clone
+}
+
 func (t Table) Header() []string {
 	return t.Meta.Headers
 }
@@ -82,17 +143,22 @@ func (t Table) Records() [][]string {
 }
 
 func (t Table) RandomRow() ([]string, int, error) {
+	return t.RandomRowWith(nil)
+}
+
+//RandomRowWith returns a random row the same as RandomRow, using r for every roll. A nil r uses the dice package.
+func (t Table) RandomRowWith(r Rand) ([]string, int, error) {
 	dieRoll := 0
 
 	if t.Meta.RollableTable {
-		_, dieRoll, _ = dice.RollExpression(t.Meta.RollExpression)
+		dieRoll, _ = rollWith(r, t.Meta.RollExpression)
 	} else {
 		//in the past we didn't allow random rows if table not rollable, but now we want to
 		rollExpression := fmt.Sprintf("1d%d", len(t.Rows))
-		_, dieRoll, _ = dice.RollExpression(rollExpression)
+		dieRoll, _ = rollWith(r, rollExpression)
 	}
 
-	row, err := t.GetRow(dieRoll)
+	row, err := t.GetRowWith(r, dieRoll)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -101,37 +167,49 @@ func (t Table) RandomRow() ([]string, int, error) {
 }
 
 func (t Table) GetRow(roll int) ([]string, error) {
-	for _, row := range t.Rows {
+	return t.GetRowWith(nil, roll)
+}
+
+//GetRowWith returns the row for roll the same as GetRow, using r to roll any roll expressions in the row.
+//Named captures (e.g. {{n=1d6}}) are rolled once and shared by every cell of the row. A nil r uses the dice package.
+func (t Table) GetRowWith(r Rand, roll int) ([]string, error) {
+	index := t.rowIndex(roll)
+	if index < 0 {
+		return nil, ErrInvalidTableRollValue
+	}
+
+	row := t.Rows[index]
+	if row.HasRollExpression {
+		return rollRowWith(r, row.Results), nil
+	}
+
+	return row.Results, nil
+}
+
+//rowIndex returns the index of the row for the roll provided, or -1 if no row matches.
+func (t Table) rowIndex(roll int) int {
+	for i, row := range t.Rows {
 		if row.DieRoll == roll {
-			if row.HasRollExpression {
-				var rolledResults []string
-				for _, result := range row.Results {
-					rolledResults = append(rolledResults, dice.RollString(result))
-				}
-				return rolledResults, nil
-			}
-			return row.Results, nil
+			return i
 		}
 	}
 
 	//this means we didn't find a row with the roll requested, so let's check again with ranges
-	for _, row := range t.Rows {
+	for i, row := range t.Rows {
 		if RollInRange(roll, row.RollRange) {
-			if row.HasRollExpression {
-				var rolledResults []string
-				for _, result := range row.Results {
-					rolledResults = append(rolledResults, dice.RollString(result))
-				}
-				return rolledResults, nil
-			}
-			return row.Results, nil
+			return i
 		}
 	}
 
-	return nil, ErrInvalidTableRollValue
+	return -1
 }
 
 func (t Table) Expression(te string) ([][]string, error) {
+	return t.ExpressionWith(nil, te)
+}
+
+//ExpressionWith runs the table expression the same as Expression, using r for every roll. A nil r uses the dice package.
+func (t Table) ExpressionWith(r Rand, te string) ([][]string, error) {
 	if !t.Meta.RollableTable {
 		return nil, ErrTableNotRollable
 	}
@@ -167,7 +245,7 @@ func (t Table) Expression(te string) ([][]string, error) {
 		if wantsUnique {
 			var previousRolls []int
 			for i := 0; i < number; i++ {
-				row, roll, err := t.RandomRow()
+				row, roll, err := t.RandomRowWith(r)
 				if err != nil {
 					return nil, err
 				}
@@ -188,7 +266,7 @@ func (t Table) Expression(te string) ([][]string, error) {
 		}
 
 		for i := 0; i < number; i++ {
-			row, _, err := t.RandomRow()
+			row, _, err := t.RandomRowWith(r)
 			if err != nil {
 				return nil, err
 			}
@@ -198,7 +276,7 @@ func (t Table) Expression(te string) ([][]string, error) {
 		return data, nil
 	}
 
-	row, err := t.GetRow(number)
+	row, err := t.GetRowWith(r, number)
 	if err != nil {
 		return nil, err
 	}
@@ -208,61 +286,91 @@ func (t Table) Expression(te string) ([][]string, error) {
 	return data, nil
 }
 
+//Hash returns the identity key of the table, an MD5 hash of its name. It does not change when the table is edited, use ContentHash for that.
 func (t Table) Hash() string {
 	return fmt.Sprintf("%x", md5.Sum([]byte(t.Meta.Name)))
 }
 
 //Load returns a Table loaded with the provided records as its rows. The first record will be used as its header.
 //Providing a roll expression allow this table to be "rolled" using table expressions (e.g. 2?tablename, 4#tablename).
+//Headers can be annotated with the type of their column (e.g. Cost:currency, see ColumnType), and every cell must
+//match the type of its column. Columns with a header starting with MetaColumnPrefix (e.g. @source) are loaded into
+//the meta data of each row instead of its results, and columns with a header starting with GMColumnPrefix
+//(e.g. !Secret) are only visible to AudienceGM (see View).
 func Load(records [][]string, name, displayName, rollExpression string) (Table, error) {
 	var headers []string
-	var err error
+	var columnTypes []ColumnType
+	var visibility []Audience
 	table := Table{}
 	rollable := (rollExpression != "")
 
+	var metaHeaders []string
+	var meta []int
 	for i, row := range records {
 		if i == 0 {
-			headers = append(headers, row...)
+			metaHeaders, meta = row, metaColumns(row)
+			headers, _ = splitMeta(row, metaHeaders, meta)
+			headers, visibility = splitVisibility(headers)
+			headers, columnTypes = splitHeaders(append([]string(nil), headers...))
 			continue
 		}
 
-		dieRoll := i
-		rollRange := ""
-		if rollable {
-			dieRoll = 0
-			if RangedRoll(row[0]) {
-				rollRange = row[0]
-				//we will set dieRoll to the range start for sorting purposes
-				parts := strings.Split(row[0], "-")
-				dieRoll, _ = strconv.Atoi(parts[0])
-			} else {
-				dieRoll, err = strconv.Atoi(row[0])
-				if err != nil {
-					return Table{}, ErrInvalidRollColumn
-				}
-			}
+		results, rowMeta := splitMeta(row, metaHeaders, meta)
+		tableRow, err := newRow(results, i, rollable)
+		if err != nil {
+			return Table{}, err
 		}
+		tableRow.Meta = rowMeta
+		table.Rows = append(table.Rows, tableRow)
+	}
+
+	table.Meta = Meta{Name: name, DisplayName: displayName, Headers: headers, ColumnTypes: columnTypes, Visibility: visibility, ColumnCount: len(headers), RollableTable: rollable, RollExpression: rollExpression}
+
+	err := table.checkColumnTypes()
+	if err != nil {
+		return Table{}, err
+	}
+
+	return table, nil
+}
 
-		hasRollExpression := false
-		for _, column := range row {
-			if RollableString(column) {
-				hasRollExpression = true
-				break
+//newRow returns a Row for the provided record with its derived fields set. For rollable tables the die roll and
+//range are read from the first column, otherwise dieRoll (the record's position in the table) is used.
+func newRow(record []string, dieRoll int, rollable bool) (Row, error) {
+	rollRange := ""
+	if rollable {
+		if len(record) == 0 {
+			return Row{}, ErrInvalidRollColumn
+		}
+		dieRoll = 0
+		if RangedRoll(record[0]) {
+			rollRange = record[0]
+			//we will set dieRoll to the range start for sorting purposes
+			parts := strings.Split(record[0], "-")
+			dieRoll, _ = strconv.Atoi(parts[0])
+		} else {
+			var err error
+			dieRoll, err = strconv.Atoi(record[0])
+			if err != nil {
+				return Row{}, ErrInvalidRollColumn
 			}
 		}
-
-		tableRow := Row{DieRoll: dieRoll, RollRange: rollRange, HasRollExpression: hasRollExpression, Results: row}
-		table.Rows = append(table.Rows, tableRow)
 	}
 
-	table.Meta = Meta{Name: name, DisplayName: displayName, Headers: headers, ColumnCount: len(headers), RollableTable: rollable, RollExpression: rollExpression}
+	hasRollExpression := false
+	for _, column := range record {
+		if RollableString(column) {
+			hasRollExpression = true
+			break
+		}
+	}
 
-	return table, nil
+	return Row{DieRoll: dieRoll, RollRange: rollRange, HasRollExpression: hasRollExpression, Results: record}, nil
 }
 
-//RollableString returns true if value contains a roll expression.
+//RollableString returns true if value contains a roll expression or a named capture (e.g. {{n=1d6}}).
 func RollableString(value string) bool {
-	return dice.ContainsRollExpressionBracedRE.MatchString(value)
+	return dice.ContainsRollExpressionBracedRE.MatchString(value) || RowCaptureRE.MatchString(value)
 }
 
 //RangedRoll returns true if value is a valid ranged roll.
@@ -303,6 +411,25 @@ func RollInRange(value int, rollRange string) bool {
 	return false
 }
 
+//ExpressionRows returns the number of rows the table expression rolls (e.g. 3 for 3?tablename, 1 for 4#tablename), or
+//0 if it is not a table expression. A count too large to read is returned as math.MaxInt.
+func ExpressionRows(te string) int {
+	match := TableRollExpressionRE.FindStringSubmatch(strings.TrimPrefix(strings.TrimSpace(te), "uni:"))
+	switch {
+	case match == nil:
+		return 0
+	case match[2] == "#" || match[1] == "":
+		return 1
+	}
+
+	number, err := strconv.Atoi(match[1])
+	if err != nil {
+		return math.MaxInt
+	}
+
+	return max(number, 1)
+}
+
 //ParseTablename returns the tablename from a table expression.
 func ParseTablename(te string) string {
 	if strings.HasPrefix(te, "uni:") {
@@ -317,6 +444,15 @@ func ParseTablename(te string) string {
 	return match[3]
 }
 
+//cloneStrings returns a copy of values, keeping nil as nil.
+func cloneStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	return append(make([]string, 0, len(values)), values...)
+}
+
 func containsRoll(i []int, roll int) bool {
 	for _, v := range i {
 		if v == roll {
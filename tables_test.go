@@ -1,6 +1,7 @@
 package tables
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -692,6 +693,28 @@ func TestTable_Expression(t *testing.T) {
 	})
 }
 
+func TestTable_Clone(t *testing.T) {
+	t.Run("validate changes to a clone are not seen by the original", func(t *testing.T) {
+		table, _ := Load(testCSV, "test", "Test", "d6")
+		table.AddLocale("es", testCSV, "Prueba")
+		want, _ := Load(testCSV, "test", "Test", "d6")
+		want.AddLocale("es", testCSV, "Prueba")
+
+		clone := table.Clone()
+		if !reflect.DeepEqual(table, clone) {
+			t.Errorf("want %v, got %v", table, clone)
+		}
+
+		clone.Meta.Headers[0] = "D8"
+		clone.Meta.Locales["es"].Headers[0] = "D8"
+		clone.Rows[0].Results[1] = "changed"
+		clone.Rows[0].Locales["es"][1] = "cambiado"
+		if !reflect.DeepEqual(want, table) {
+			t.Errorf("want %v, got %v", want, table)
+		}
+	})
+}
+
 func Test_RollableString(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -884,6 +907,30 @@ func Test_ParseTablename(t *testing.T) {
 	}
 }
 
+func Test_ExpressionRows(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		want       int
+	}{
+		{name: "validate random rows are counted", expression: "uni:3?loot", want: 3},
+		{name: "validate a specific row is one row", expression: "12#loot", want: 1},
+		{name: "validate a random row without a count is one row", expression: "?loot", want: 1},
+		{name: "validate a count too large to read is the most rows", expression: "99999999999999999999?loot", want: math.MaxInt},
+		{name: "validate an invalid expression has no rows", expression: "loot", want: 0},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got := ExpressionRows(test.expression)
+
+			if got != test.want {
+				t.Errorf("want %d, got %d", test.want, got)
+			}
+		})
+	}
+}
+
 func Test_containsRoll(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -0,0 +1,125 @@
+package tablesrpc
+
+import "github.com/fantastical-world/tables"
+
+//FromTable returns the protobuf message for a table.
+func FromTable(t tables.Table) *Table {
+	table := &Table{Meta: FromMeta(t.Meta)}
+	for _, row := range t.Rows {
+		var locales map[string]*Result
+		for locale, results := range row.Locales {
+			if locales == nil {
+				locales = make(map[string]*Result, len(row.Locales))
+			}
+			locales[locale] = &Result{Values: results}
+		}
+
+		table.Rows = append(table.Rows, &Row{
+			DieRoll:           int32(row.DieRoll),
+			RollRange:         row.RollRange,
+			HasRollExpression: row.HasRollExpression,
+			Results:           row.Results,
+			Locales:           locales,
+			Action:            row.Action,
+			Meta:              row.Meta,
+		})
+	}
+
+	return table
+}
+
+//FromMeta returns the protobuf message for a table's meta data.
+func FromMeta(m tables.Meta) *Meta {
+	meta := &Meta{
+		Name:           m.Name,
+		DisplayName:    m.DisplayName,
+		Title:          m.Title,
+		FlavorText:     m.FlavorText,
+		Campaign:       m.Campaign,
+		Headers:        m.Headers,
+		ColumnCount:    int32(m.ColumnCount),
+		RollableTable:  m.RollableTable,
+		RollExpression: m.RollExpression,
+		Parent:         m.Parent,
+	}
+	for _, columnType := range m.ColumnTypes {
+		meta.ColumnTypes = append(meta.ColumnTypes, string(columnType))
+	}
+	for _, audience := range m.Visibility {
+		meta.Visibility = append(meta.Visibility, string(audience))
+	}
+	for locale, translation := range m.Locales {
+		if meta.Locales == nil {
+			meta.Locales = make(map[string]*MetaLocale, len(m.Locales))
+		}
+		meta.Locales[locale] = &MetaLocale{
+			DisplayName: translation.DisplayName,
+			Title:       translation.Title,
+			FlavorText:  translation.FlavorText,
+			Headers:     translation.Headers,
+		}
+	}
+
+	return meta
+}
+
+//ToTable returns the table for a protobuf message.
+func (x *Table) ToTable() tables.Table {
+	table := tables.Table{Meta: x.GetMeta().ToMeta()}
+	for _, row := range x.GetRows() {
+		var locales map[string][]string
+		for locale, results := range row.GetLocales() {
+			if locales == nil {
+				locales = make(map[string][]string, len(row.GetLocales()))
+			}
+			locales[locale] = results.GetValues()
+		}
+
+		table.Rows = append(table.Rows, tables.Row{
+			DieRoll:           int(row.GetDieRoll()),
+			RollRange:         row.GetRollRange(),
+			HasRollExpression: row.GetHasRollExpression(),
+			Results:           row.GetResults(),
+			Locales:           locales,
+			Action:            row.GetAction(),
+			Meta:              row.GetMeta(),
+		})
+	}
+
+	return table
+}
+
+//ToMeta returns the table meta data for a protobuf message.
+func (x *Meta) ToMeta() tables.Meta {
+	meta := tables.Meta{
+		Name:           x.GetName(),
+		DisplayName:    x.GetDisplayName(),
+		Title:          x.GetTitle(),
+		FlavorText:     x.GetFlavorText(),
+		Campaign:       x.GetCampaign(),
+		Headers:        x.GetHeaders(),
+		ColumnCount:    int(x.GetColumnCount()),
+		RollableTable:  x.GetRollableTable(),
+		RollExpression: x.GetRollExpression(),
+		Parent:         x.GetParent(),
+	}
+	for _, columnType := range x.GetColumnTypes() {
+		meta.ColumnTypes = append(meta.ColumnTypes, tables.ColumnType(columnType))
+	}
+	for _, audience := range x.GetVisibility() {
+		meta.Visibility = append(meta.Visibility, tables.Audience(audience))
+	}
+	for locale, translation := range x.GetLocales() {
+		if meta.Locales == nil {
+			meta.Locales = make(map[string]tables.MetaLocale, len(x.GetLocales()))
+		}
+		meta.Locales[locale] = tables.MetaLocale{
+			DisplayName: translation.GetDisplayName(),
+			Title:       translation.GetTitle(),
+			FlavorText:  translation.GetFlavorText(),
+			Headers:     translation.GetHeaders(),
+		}
+	}
+
+	return meta
+}
@@ -0,0 +1,137 @@
+//Package tablesrpc serves a library of tables over gRPC, the TableService is defined in tables.proto.
+package tablesrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative tables.proto
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fantastical-world/tables"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//MaxRollManyCount is the most results a single RollMany call streams, it keeps a client from asking for a million rolls.
+const MaxRollManyCount = 1000
+
+//Server implements TableServiceServer for a library of tables.
+type Server struct {
+	UnimplementedTableServiceServer
+	library  *tables.Library
+	audience tables.Audience
+}
+
+//NewServer returns a Server for the tables in library, register it using RegisterTableServiceServer. Each table
+//expression may roll at most tables.MaxExpressionRows rows.
+func NewServer(library *tables.Library) *Server {
+	return NewServerFor(library, tables.AudienceGM)
+}
+
+//NewServerFor returns a Server for the tables in library the same as NewServer, showing tables and rolls as audience
+//sees them (see tables.View).
+func NewServerFor(library *tables.Library, audience tables.Audience) *Server {
+	return &Server{library: library, audience: audience}
+}
+
+func (s *Server) Roll(ctx context.Context, request *RollRequest) (*RollResult, error) {
+	var rand tables.Rand
+	if request.Seed != nil {
+		rand = tables.NewRand(request.GetSeed())
+	}
+
+	return s.roll(rand, request.GetExpression())
+}
+
+func (s *Server) GetTable(ctx context.Context, request *GetTableRequest) (*Table, error) {
+	table, err := s.library.Resolve(request.GetName())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return FromTable(table.View(s.audience)), nil
+}
+
+func (s *Server) ListTables(ctx context.Context, request *ListTablesRequest) (*ListTablesResponse, error) {
+	library := s.library.Snapshot()
+	response := &ListTablesResponse{}
+	for _, name := range library.Names() {
+		//tables that can't be resolved are only listed for the gm since it isn't known what they would hide
+		table, err := library.Resolve(name)
+		if err != nil && s.audience != tables.AudienceGM {
+			continue
+		}
+		if err != nil {
+			table, _ = library.Table(name)
+		}
+		response.Tables = append(response.Tables, FromMeta(table.View(s.audience).Meta))
+	}
+
+	return response, nil
+}
+
+func (s *Server) RollMany(request *RollManyRequest, stream TableService_RollManyServer) error {
+	if request.GetCount() < 1 || request.GetCount() > MaxRollManyCount {
+		return status.Errorf(codes.InvalidArgument, "count must be from 1 to %d", MaxRollManyCount)
+	}
+
+	//a single source is used for every roll, so a seed repeats the whole stream
+	var rand tables.Rand
+	if request.Seed != nil {
+		rand = tables.NewRand(request.GetSeed())
+	}
+
+	for i := int32(0); i < request.GetCount(); i++ {
+		result, err := s.roll(rand, request.GetExpression())
+		if err != nil {
+			return err
+		}
+
+		err = stream.Send(result)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) roll(rand tables.Rand, expression string) (*RollResult, error) {
+	if tables.ExpressionRows(expression) > tables.MaxExpressionRows {
+		return nil, statusError(tables.ErrRowLimit)
+	}
+
+	library := s.library.Snapshot()
+	records, err := library.ExpressionWith(rand, expression)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	table, _ := library.Resolve(tables.ParseTablename(expression))
+	records = table.ViewRecords(records, s.audience)
+	result := &RollResult{
+		Expression:  expression,
+		Table:       table.Meta.Name,
+		DisplayName: table.Meta.DisplayName,
+		Headers:     records[0],
+	}
+	for _, record := range records[1:] {
+		result.Results = append(result.Results, &Result{Values: record})
+	}
+
+	return result, nil
+}
+
+//statusError converts an error returned by a library into a gRPC status error.
+func statusError(err error) error {
+	if errors.Is(err, tables.ErrTableDoesNotExist) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	var tableErr tables.TableError
+	if errors.As(err, &tableErr) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,300 @@
+package tablesrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/fantastical-world/tables"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+var encountersCSV = [][]string{
+	{"D6", "Result"},
+	{"1-2", "Goblins"},
+	{"3-4", "{{1d4}} wolves"},
+	{"5-6", "Nothing"},
+}
+
+func newTestClient(t *testing.T) TableServiceClient {
+	encounters, err := tables.Load(encountersCSV, "encounters", "Encounters", "d6")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	abilities, err := tables.Load([][]string{{"Ability", "Description"}, {"FUN", "Funness"}}, "abilities", "Abilities", "")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	return newTestClientFor(t, NewServer(tables.NewLibrary(encounters, abilities)))
+}
+
+//newTestClientFor returns a client connected to server over an in-memory connection.
+func newTestClientFor(t *testing.T, tableServer *Server) TableServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	RegisterTableServiceServer(server, tableServer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return NewTableServiceClient(conn)
+}
+
+func TestServer_Roll(t *testing.T) {
+	client := newTestClient(t)
+
+	t.Run("validate a table expression is rolled", func(t *testing.T) {
+		got, err := client.Roll(context.Background(), &RollRequest{Expression: "5#encounters"})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want := &RollResult{Expression: "5#encounters", Table: "encounters", DisplayName: "Encounters", Headers: []string{"D6", "Result"}, Results: []*Result{{Values: []string{"5-6", "Nothing"}}}}
+		if !proto.Equal(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate seeded rolls are repeated", func(t *testing.T) {
+		seed := int64(77)
+		want, err := client.Roll(context.Background(), &RollRequest{Expression: "6?encounters", Seed: &seed})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		got, err := client.Roll(context.Background(), &RollRequest{Expression: "6?encounters", Seed: &seed})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		if len(got.Results) != 6 {
+			t.Errorf("want 6, got %d", len(got.Results))
+		}
+		if !proto.Equal(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	testErrors := []struct {
+		name       string
+		expression string
+		want       codes.Code
+	}{
+		{name: "validate not found is returned for a table that is not in the library", expression: "2?nope", want: codes.NotFound},
+		{name: "validate invalid argument is returned for an invalid table expression", expression: "encounters", want: codes.InvalidArgument},
+		{name: "validate invalid argument is returned for too many rows", expression: "200000?encounters", want: codes.InvalidArgument},
+		{name: "validate invalid argument is returned for a table that is not rollable", expression: "2?abilities", want: codes.InvalidArgument},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := client.Roll(context.Background(), &RollRequest{Expression: test.expression})
+
+			if got := status.Code(err); got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestServer_GetTable(t *testing.T) {
+	client := newTestClient(t)
+
+	t.Run("validate a table is returned", func(t *testing.T) {
+		got, err := client.GetTable(context.Background(), &GetTableRequest{Name: "encounters"})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		want, _ := tables.Load(encountersCSV, "encounters", "Encounters", "d6")
+		if !reflect.DeepEqual(want, got.ToTable()) {
+			t.Errorf("want %v, got %v", want, got.ToTable())
+		}
+	})
+
+	t.Run("validate column types, visibility, locales, and row meta data are returned", func(t *testing.T) {
+		want, err := tables.Load([][]string{{"D2", "Trap", "!Trap DC:int", "@notes"}, {"1", "Pit", "12", "Covered with leaves."}, {"2", "Darts", "15", ""}}, "traps", "Traps", "d2")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		err = want.AddLocale("es", [][]string{{"D2", "Trampa", "CD"}, {"1", "Foso", "12"}, {"2", "Dardos", "15"}}, "Trampas")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		child := tables.Table{Meta: tables.Meta{Name: "deep_traps", Headers: want.Meta.Headers, ColumnCount: 3, Parent: "traps"}, Rows: []tables.Row{{Results: []string{"2"}, Action: tables.RowRemove}}}
+		client := newTestClientFor(t, NewServer(tables.NewLibrary(want, child)))
+
+		got, err := client.GetTable(context.Background(), &GetTableRequest{Name: "traps"})
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if !reflect.DeepEqual(want, got.ToTable()) {
+			t.Errorf("want %v, got %v", want, got.ToTable())
+		}
+
+		list, err := client.ListTables(context.Background(), &ListTablesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if got := list.GetTables()[0].ToMeta(); got.Parent != "traps" {
+			t.Errorf("want traps, got %v", got)
+		}
+		if got := FromTable(child).ToTable(); !reflect.DeepEqual(child, got) {
+			t.Errorf("want %v, got %v", child, got)
+		}
+	})
+
+	t.Run("validate not found is returned for a table that is not in the library", func(t *testing.T) {
+		_, err := client.GetTable(context.Background(), &GetTableRequest{Name: "nope"})
+
+		if got := status.Code(err); got != codes.NotFound {
+			t.Errorf("want %s, got %s", codes.NotFound, got)
+		}
+	})
+}
+
+func TestServer_ListTables(t *testing.T) {
+	client := newTestClient(t)
+
+	t.Run("validate every table is listed", func(t *testing.T) {
+		got, err := client.ListTables(context.Background(), &ListTablesRequest{})
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+
+		var names []string
+		for _, meta := range got.GetTables() {
+			names = append(names, meta.GetName())
+		}
+		want := []string{"abilities", "encounters"}
+		if !reflect.DeepEqual(want, names) {
+			t.Errorf("want %v, got %v", want, names)
+		}
+	})
+}
+
+func TestServer_RollMany(t *testing.T) {
+	client := newTestClient(t)
+
+	t.Run("validate a result is streamed for every roll", func(t *testing.T) {
+		stream, err := client.RollMany(context.Background(), &RollManyRequest{Expression: "2?encounters", Count: 25})
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		got := 0
+		for {
+			result, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+			if len(result.GetResults()) != 2 {
+				t.Errorf("want 2, got %d", len(result.GetResults()))
+			}
+			got++
+		}
+
+		if got != 25 {
+			t.Errorf("want 25, got %d", got)
+		}
+	})
+
+	testErrors := []struct {
+		name    string
+		request *RollManyRequest
+		want    codes.Code
+	}{
+		{name: "validate invalid argument is returned for a count less than 1", request: &RollManyRequest{Expression: "2?encounters"}, want: codes.InvalidArgument},
+		{name: "validate invalid argument is returned for too many rows in the expression", request: &RollManyRequest{Expression: "2000?encounters", Count: 2}, want: codes.InvalidArgument},
+		{name: "validate invalid argument is returned for a count more than the maximum", request: &RollManyRequest{Expression: "2?encounters", Count: MaxRollManyCount + 1}, want: codes.InvalidArgument},
+		{name: "validate not found is returned for a table that is not in the library", request: &RollManyRequest{Expression: "2?nope", Count: 3}, want: codes.NotFound},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			stream, err := client.RollMany(context.Background(), test.request)
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+
+			_, err = stream.Recv()
+			if got := status.Code(err); got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestNewServerFor(t *testing.T) {
+	traps, err := tables.Load([][]string{{"D2", "Trap", "!Trap DC"}, {"1", "Pit", "12"}, {"2", "Darts", "15"}}, "traps", "Traps", "d2")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	deepTraps, err := tables.Load([][]string{{"D2", "Trap", "Trap DC"}, {"2", "Needles", "20"}}, "deep_traps", "Deep Traps", "d2")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	deepTraps.Meta.Parent = "traps"
+	client := newTestClientFor(t, NewServerFor(tables.NewLibrary(traps, deepTraps), tables.AudiencePlayer))
+
+	t.Run("validate hidden columns are not listed", func(t *testing.T) {
+		got, err := client.ListTables(context.Background(), &ListTablesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		if len(got.GetTables()) != 2 {
+			t.Fatalf("want deep_traps and traps, got %v", got.GetTables())
+		}
+		for _, meta := range got.GetTables() {
+			if want := []string{"D2", "Trap"}; !reflect.DeepEqual(want, meta.GetHeaders()) {
+				t.Errorf("want %v, got %v", want, meta.GetHeaders())
+			}
+		}
+	})
+
+	t.Run("validate hidden columns inherited by a child are not returned", func(t *testing.T) {
+		got, err := client.GetTable(context.Background(), &GetTableRequest{Name: "deep_traps"})
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		want := [][]string{{"D2", "Trap"}, {"1", "Pit"}, {"2", "Needles"}}
+		if !reflect.DeepEqual(want, got.ToTable().Records()) {
+			t.Errorf("want %v, got %v", want, got.ToTable().Records())
+		}
+	})
+
+	t.Run("validate hidden columns are not rolled", func(t *testing.T) {
+		stream, err := client.RollMany(context.Background(), &RollManyRequest{Expression: "2#deep_traps", Count: 1})
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		got, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		want := &RollResult{Expression: "2#deep_traps", Table: "deep_traps", DisplayName: "Deep Traps", Headers: []string{"D2", "Trap"}, Results: []*Result{{Values: []string{"2", "Needles"}}}}
+		if !proto.Equal(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+}
@@ -0,0 +1,534 @@
+package tables
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const ErrInvalidTemplate = TableError("not a valid template")
+const ErrUndefinedVariable = TableError("template variable is not defined")
+const ErrTemplateRepeatLimit = TableError("repeat count is more than a template allows")
+
+//MaxTemplateRepeat is the most times a repeat block in a template will be executed.
+const MaxTemplateRepeat = 1000
+
+var templateVariableRE = regexp.MustCompile(`^\$[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+//Template is text with embedded table and dice expressions, used to generate whole documents (e.g. an NPC or a
+//dungeon room) from a library of tables. Tags are written in braces:
+//
+//	{?adjectives}              rolls the table expression, showing the first result column
+//	{2?treasure[Item]}         shows the Item column, multiple rows are separated by commas
+//	{1d6} or {{1d6}}           rolls the dice expression
+//	{$hero=?heroes}            rolls once and stores the result, nothing is shown
+//	{$hero} {$hero[Class]}     shows a stored result, or one of its columns
+//	{if $n > 3}...{else}...{end}
+//	{repeat 1d4}...{end}
+//
+//Conditions compare two values with ==, !=, <, <=, >, or >=, numerically if both are integers. Values can be
+//variables, expressions, integers, or quoted text. A condition with a single value is true if the value is not empty.
+//Braces are escaped with a backslash (e.g. \{).
+type Template struct {
+	nodes []templateNode
+}
+
+//ParseTemplate parses text as a Template, ErrInvalidTemplate is returned if it is not valid.
+func ParseTemplate(text string) (*Template, error) {
+	tokens, err := lexTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &templateParser{tokens: tokens}
+	nodes, terminator, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if terminator != nil {
+		return nil, terminator.invalid("unexpected {%s}", terminator.text)
+	}
+
+	return &Template{nodes: nodes}, nil
+}
+
+//Execute generates text from the template, rolling its expressions against library using r.
+//A nil r uses the dice package. Variables only last for a single execution.
+func (t *Template) Execute(library *Library, r Rand) (string, error) {
+	//a snapshot keeps the tables the same for the whole execution, even if the library is changed
+	s := &templateState{library: library.Snapshot(), rand: r, variables: make(map[string]templateValue)}
+
+	var b strings.Builder
+	err := s.execute(&b, t.nodes)
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+type templateToken struct {
+	text   string
+	tag    bool
+	offset int
+}
+
+func (t templateToken) invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w, %s at offset %d", ErrInvalidTemplate, fmt.Sprintf(format, args...), t.offset)
+}
+
+//lexTemplate splits text into text and tag tokens, tags have their braces and surrounding spaces removed.
+func lexTemplate(text string) ([]templateToken, error) {
+	var tokens []templateToken
+	var literal strings.Builder
+	literalOffset := 0
+
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && i+1 < len(text) && (text[i+1] == '{' || text[i+1] == '}'):
+			i++
+			literal.WriteByte(text[i])
+		case text[i] == '{':
+			open, close := "{", "}"
+			if strings.HasPrefix(text[i:], "{{") {
+				open, close = "{{", "}}"
+			}
+			end := strings.Index(text[i+len(open):], close)
+			if end == -1 {
+				return nil, templateToken{offset: i}.invalid("unclosed tag")
+			}
+
+			if literal.Len() > 0 {
+				tokens = append(tokens, templateToken{text: literal.String(), offset: literalOffset})
+				literal.Reset()
+			}
+			tag := strings.TrimSpace(text[i+len(open) : i+len(open)+end])
+			tokens = append(tokens, templateToken{text: tag, tag: true, offset: i})
+			i += len(open) + end + len(close) - 1
+			literalOffset = i + 1
+		default:
+			literal.WriteByte(text[i])
+		}
+	}
+
+	if literal.Len() > 0 {
+		tokens = append(tokens, templateToken{text: literal.String(), offset: literalOffset})
+	}
+
+	return tokens, nil
+}
+
+type templateParser struct {
+	tokens []templateToken
+	pos    int
+}
+
+//parse returns the nodes up to the next {else} or {end} tag, which is returned as the terminator.
+func (p *templateParser) parse() ([]templateNode, *templateToken, error) {
+	var nodes []templateNode
+	for p.pos < len(p.tokens) {
+		token := p.tokens[p.pos]
+		p.pos++
+
+		if !token.tag {
+			nodes = append(nodes, textNode(token.text))
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(token.text, " ")
+		switch {
+		case token.text == "end" || token.text == "else":
+			return nodes, &token, nil
+		case keyword == "if":
+			node, err := p.parseIf(token, strings.TrimSpace(rest))
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, node)
+		case keyword == "repeat":
+			node, err := p.parseRepeat(token, strings.TrimSpace(rest))
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, node)
+		case strings.HasPrefix(token.text, "$") && strings.Contains(token.text, "="):
+			name, value, _ := strings.Cut(token.text, "=")
+			name = strings.TrimSpace(name)
+			if !templateVariableRE.MatchString(name) {
+				return nil, nil, token.invalid("invalid variable name %q", name)
+			}
+			operand, err := parseOperand(token, strings.TrimSpace(value), false)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, assignNode{name: name, value: operand})
+		default:
+			operand, err := parseOperand(token, token.text, false)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, operandNode{operand})
+		}
+	}
+
+	return nodes, nil, nil
+}
+
+func (p *templateParser) parseIf(token templateToken, condition string) (templateNode, error) {
+	node := ifNode{}
+	var err error
+	node.left, node.op, node.right, err = parseCondition(token, condition)
+	if err != nil {
+		return nil, err
+	}
+
+	var terminator *templateToken
+	node.then, terminator, err = p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if terminator != nil && terminator.text == "else" {
+		node.otherwise, terminator, err = p.parse()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if terminator == nil || terminator.text != "end" {
+		return nil, token.invalid("{if} without {end}")
+	}
+
+	return node, nil
+}
+
+func (p *templateParser) parseRepeat(token templateToken, count string) (templateNode, error) {
+	operand, err := parseOperand(token, count, true)
+	if err != nil {
+		return nil, err
+	}
+
+	body, terminator, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if terminator == nil || terminator.text != "end" {
+		return nil, token.invalid("{repeat} without {end}")
+	}
+
+	return repeatNode{count: operand, body: body}, nil
+}
+
+//parseCondition splits a condition into its operands and comparison operator, the operator is empty for a
+//condition with a single value.
+func parseCondition(token templateToken, condition string) (templateOperand, string, templateOperand, error) {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		left, right, found := strings.Cut(condition, op)
+		if !found {
+			continue
+		}
+
+		l, err := parseOperand(token, strings.TrimSpace(left), true)
+		if err != nil {
+			return templateOperand{}, "", templateOperand{}, err
+		}
+		r, err := parseOperand(token, strings.TrimSpace(right), true)
+		if err != nil {
+			return templateOperand{}, "", templateOperand{}, err
+		}
+
+		return l, op, r, nil
+	}
+
+	operand, err := parseOperand(token, condition, true)
+	return operand, "", templateOperand{}, err
+}
+
+//templateOperand is a value in a template, only one of variable, table, dice, or literal is set.
+type templateOperand struct {
+	variable string
+	table    string
+	dice     string
+	literal  *string
+	column   string
+}
+
+//parseOperand parses a variable, table expression, or dice expression with an optional [Column] selector.
+//When literals is true, integers and quoted or bare text are also accepted.
+func parseOperand(token templateToken, value string, literals bool) (templateOperand, error) {
+	if value == "" {
+		return templateOperand{}, token.invalid("missing value")
+	}
+
+	operand := templateOperand{}
+	if start := strings.Index(value, "["); start > 0 && strings.HasSuffix(value, "]") {
+		operand.column = strings.TrimSpace(value[start+1 : len(value)-1])
+		value = value[:start]
+	}
+
+	switch {
+	case strings.HasPrefix(value, "$"):
+		if !templateVariableRE.MatchString(value) {
+			return templateOperand{}, token.invalid("invalid variable name %q", value)
+		}
+		operand.variable = value
+		return operand, nil
+	case TableRollExpressionRE.MatchString(strings.TrimPrefix(value, "uni:")):
+		operand.table = value
+		return operand, nil
+	}
+
+	if operand.column != "" {
+		return templateOperand{}, token.invalid("%q does not have columns", value)
+	}
+
+	switch {
+	case diceExpression(value):
+		operand.dice = value
+	case literals:
+		if len(value) > 1 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		operand.literal = &value
+	default:
+		return templateOperand{}, token.invalid("%q is not a variable, table expression, or dice expression", value)
+	}
+
+	return operand, nil
+}
+
+//templateValue is the result of evaluating an operand. Table results keep every rolled column, so a stored
+//variable can be shown by column later.
+type templateValue struct {
+	text    string
+	headers []string
+	rows    [][]string
+}
+
+func (v templateValue) String() string {
+	if v.rows == nil {
+		return v.text
+	}
+
+	column := 0
+	if len(v.headers) > 1 {
+		//the first column of a rollable table is its roll, show the first result instead
+		column = 1
+	}
+
+	return v.join(column)
+}
+
+func (v templateValue) join(column int) string {
+	var values []string
+	for _, row := range v.rows {
+		if column < len(row) {
+			values = append(values, row[column])
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+//selectColumn returns a value with the text of the named column, matching headers exactly and then ignoring case.
+func (v templateValue) selectColumn(name string) (templateValue, error) {
+	if v.rows == nil {
+		return templateValue{}, ErrColumnDoesNotExist
+	}
+
+	for _, equal := range []func(a, b string) bool{func(a, b string) bool { return a == b }, strings.EqualFold} {
+		for i, header := range v.headers {
+			if equal(header, name) {
+				return templateValue{text: v.join(i)}, nil
+			}
+		}
+	}
+
+	return templateValue{}, ErrColumnDoesNotExist
+}
+
+type templateState struct {
+	library   *Library
+	rand      Rand
+	variables map[string]templateValue
+}
+
+func (s *templateState) execute(b *strings.Builder, nodes []templateNode) error {
+	for _, node := range nodes {
+		err := node.execute(s, b)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *templateState) evaluate(operand templateOperand) (templateValue, error) {
+	var value templateValue
+	switch {
+	case operand.variable != "":
+		var ok bool
+		value, ok = s.variables[operand.variable]
+		if !ok {
+			return templateValue{}, fmt.Errorf("%s: %w", operand.variable, ErrUndefinedVariable)
+		}
+	case operand.table != "":
+		table, err := s.library.Resolve(ParseTablename(operand.table))
+		if err != nil {
+			return templateValue{}, fmt.Errorf("%s: %w", operand.table, err)
+		}
+		records, err := table.ExpressionWith(s.rand, operand.table)
+		if err != nil {
+			return templateValue{}, fmt.Errorf("%s: %w", operand.table, err)
+		}
+		value = templateValue{headers: records[0], rows: records[1:]}
+	case operand.dice != "":
+		total, err := rollWith(s.rand, operand.dice)
+		if err != nil {
+			return templateValue{}, fmt.Errorf("%s: %w", operand.dice, err)
+		}
+		value = templateValue{text: strconv.Itoa(total)}
+	default:
+		value = templateValue{text: *operand.literal}
+	}
+
+	if operand.column == "" {
+		return value, nil
+	}
+
+	value, err := value.selectColumn(operand.column)
+	if err != nil {
+		return templateValue{}, fmt.Errorf("%s: %w", operand.column, err)
+	}
+
+	return value, nil
+}
+
+type templateNode interface {
+	execute(s *templateState, b *strings.Builder) error
+}
+
+type textNode string
+
+func (n textNode) execute(s *templateState, b *strings.Builder) error {
+	b.WriteString(string(n))
+	return nil
+}
+
+type operandNode struct {
+	operand templateOperand
+}
+
+func (n operandNode) execute(s *templateState, b *strings.Builder) error {
+	value, err := s.evaluate(n.operand)
+	if err != nil {
+		return err
+	}
+
+	b.WriteString(value.String())
+	return nil
+}
+
+type assignNode struct {
+	name  string
+	value templateOperand
+}
+
+func (n assignNode) execute(s *templateState, b *strings.Builder) error {
+	value, err := s.evaluate(n.value)
+	if err != nil {
+		return err
+	}
+
+	s.variables[n.name] = value
+	return nil
+}
+
+type ifNode struct {
+	left, right     templateOperand
+	op              string
+	then, otherwise []templateNode
+}
+
+func (n ifNode) execute(s *templateState, b *strings.Builder) error {
+	left, err := s.evaluate(n.left)
+	if err != nil {
+		return err
+	}
+
+	result := left.String() != ""
+	if n.op != "" {
+		right, err := s.evaluate(n.right)
+		if err != nil {
+			return err
+		}
+		result = compare(left.String(), n.op, right.String())
+	}
+
+	if result {
+		return s.execute(b, n.then)
+	}
+
+	return s.execute(b, n.otherwise)
+}
+
+//compare compares a and b using op, numerically if both are integers.
+func compare(a, op, b string) bool {
+	c := strings.Compare(a, b)
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			c = -1
+		case x > y:
+			c = 1
+		default:
+			c = 0
+		}
+	}
+
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+type repeatNode struct {
+	count templateOperand
+	body  []templateNode
+}
+
+func (n repeatNode) execute(s *templateState, b *strings.Builder) error {
+	value, err := s.evaluate(n.count)
+	if err != nil {
+		return err
+	}
+
+	count, err := strconv.Atoi(value.String())
+	if err != nil {
+		return fmt.Errorf("%w, repeat count %q is not an integer", ErrInvalidTemplate, value.String())
+	}
+	if count > MaxTemplateRepeat {
+		return ErrTemplateRepeatLimit
+	}
+
+	for i := 0; i < count; i++ {
+		err := s.execute(b, n.body)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package tables
+
+import (
+	"errors"
+	"testing"
+)
+
+var heroesCSV = [][]string{
+	{"D2", "Name", "Class"},
+	{"1", "Ayla", "Knight"},
+	{"2", "Bram", "Thief"},
+}
+
+func testTemplateLibrary() *Library {
+	encounters, _ := Load(testCSV, "encounters", "Encounters", "d6")
+	heroes, _ := Load(heroesCSV, "heroes", "Heroes", "d2")
+	return NewLibrary(encounters, heroes)
+}
+
+func TestTemplate_Execute(t *testing.T) {
+	library := testTemplateLibrary()
+
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+	}{
+		{name: "validate text without tags is unchanged", template: "A quiet road.", want: []string{"A quiet road."}},
+		{name: "validate table expressions show the first result column", template: "You see: {2#encounters}.", want: []string{"You see: No encounter."}},
+		{name: "validate a column can be selected", template: "{4#encounters[Description]}", want: []string{"Angry bats swarm and attack the party."}},
+		{name: "validate multiple rows are separated by commas", template: "{uni:2?heroes[name]}", want: []string{"Ayla, Bram", "Bram, Ayla"}},
+		{name: "validate dice expressions are rolled", template: "{1d1+4} gold and {{2d1}} silver", want: []string{"5 gold and 2 silver"}},
+		{name: "validate variables are rolled once and reused", template: "{$hero=?heroes}{$hero[Name]} the {$hero[Class]}", want: []string{"Ayla the Knight", "Bram the Thief"}},
+		{name: "validate conditionals compare numbers", template: "{$n=1d1+9}{if $n > 9}big{else}small{end}", want: []string{"big"}},
+		{name: "validate conditionals compare text", template: "{if 1#heroes[Class] == \"Knight\"}sir{end}{if 2#heroes[Class] != Thief}sir{end}", want: []string{"sir"}},
+		{name: "validate conditionals without an else show nothing", template: "[{if 1d1 < 1}never{end}]", want: []string{"[]"}},
+		{name: "validate loops repeat their body", template: "{repeat 3}ha{end}{repeat 1d1}!{end}", want: []string{"hahaha!"}},
+		{name: "validate blocks can be nested", template: "{repeat 2}{if 1d1 == 1}{$x=1d1}{$x}{end}{end}", want: []string{"11"}},
+		{name: "validate braces can be escaped", template: `\{1d6\}`, want: []string{"{1d6}"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			template, err := ParseTemplate(test.template)
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+
+			got, err := template.Execute(library, NewRand(1))
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+
+			if !containsString(test.want, got) {
+				t.Errorf("want one of %v, got %s", test.want, got)
+			}
+		})
+	}
+
+	t.Run("validate seeded templates are repeated", func(t *testing.T) {
+		template, _ := ParseTemplate("{3?encounters} {2d20} {repeat 1d4}{?heroes}{end}")
+
+		want, _ := template.Execute(library, NewRand(9))
+		got, _ := template.Execute(library, NewRand(9))
+		if want != got {
+			t.Errorf("want %s, got %s", want, got)
+		}
+	})
+
+	testErrors := []struct {
+		name     string
+		template string
+		want     error
+	}{
+		{name: "validate an error is returned for an undefined variable", template: "{$nope}", want: ErrUndefinedVariable},
+		{name: "validate an error is returned for a table that is not in the library", template: "{?nope}", want: ErrTableDoesNotExist},
+		{name: "validate an error is returned for a column that does not exist", template: "{?heroes[Level]}", want: ErrColumnDoesNotExist},
+		{name: "validate an error is returned for a repeat count that is not an integer", template: "{repeat ?heroes}x{end}", want: ErrInvalidTemplate},
+		{name: "validate an error is returned for a repeat count over the limit", template: "{repeat 1001}x{end}", want: ErrTemplateRepeatLimit},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			template, err := ParseTemplate(test.template)
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+
+			_, err = template.Execute(library, nil)
+			if !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestParseTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{name: "validate an unclosed tag is invalid", template: "The {?adjectives guard"},
+		{name: "validate an if without an end is invalid", template: "{if $x}yes"},
+		{name: "validate a repeat without an end is invalid", template: "{repeat 2}yes{else}no{end}"},
+		{name: "validate an unexpected end is invalid", template: "yes{end}"},
+		{name: "validate an unknown tag is invalid", template: "{adjectives}"},
+		{name: "validate an invalid variable name is invalid", template: "{$1st=1d6}"},
+		{name: "validate a column on a dice expression is invalid", template: "{1d6[Item]}"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseTemplate(test.template)
+			if !errors.Is(err, ErrInvalidTemplate) {
+				t.Errorf("want %v, got %v", ErrInvalidTemplate, err)
+			}
+		})
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
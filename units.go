@@ -0,0 +1,214 @@
+package tables
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const ErrInvalidUnitSystem = TableError("not a valid unit system")
+const ErrUnitSystemDoesNotExist = TableError("unit system does not exist")
+const ErrUnitDoesNotExist = TableError("unit is not part of the unit system")
+
+//Unit is a denomination of a UnitSystem, such as gold pieces or pounds.
+type Unit struct {
+	Name string
+	//Aliases are other names the unit can be written as (e.g. lbs for lb).
+	Aliases []string
+	//Value is the number of base units in one of this unit.
+	Value float64
+}
+
+//UnitSystem converts between the units of something that can be counted, such as coins or weight. Amounts are
+//normalized to the system's base unit, the unit with a value of 1.
+type UnitSystem struct {
+	Name  string
+	Units []Unit
+	//Preferred is the unit amounts are formatted in, the base unit if it is empty.
+	Preferred string
+}
+
+//Coins are the copper, silver, electrum, gold, and platinum pieces common to fantasy games, normalized to copper.
+var Coins = UnitSystem{
+	Name: "coins",
+	Units: []Unit{
+		{Name: "cp", Value: 1},
+		{Name: "sp", Value: 10},
+		{Name: "ep", Value: 50},
+		{Name: "gp", Value: 100},
+		{Name: "pp", Value: 1000},
+	},
+	Preferred: "gp",
+}
+
+//Weight is ounces and pounds, normalized to ounces.
+var Weight = UnitSystem{
+	Name: "weight",
+	Units: []Unit{
+		{Name: "oz", Value: 1},
+		{Name: "lb", Aliases: []string{"lbs"}, Value: 16},
+	},
+	Preferred: "lb",
+}
+
+var amountRE = regexp.MustCompile(`^([+-]?\d[\d,]*(?:\.\d+)?)\s*([a-zA-Z]*)[\s,]*`)
+
+//unitSystems are the unit systems that can be used by value columns (e.g. Cost:value:coins).
+var unitSystems = struct {
+	sync.RWMutex
+	systems map[string]UnitSystem
+}{systems: map[string]UnitSystem{Coins.Name: Coins, Weight.Name: Weight}}
+
+//RegisterUnitSystem adds a unit system that can be used by value columns, replacing any system with the same name.
+//A system must have a name, exactly one unit with a value of 1, and units with unique names and positive values.
+func RegisterUnitSystem(system UnitSystem) error {
+	if system.Name == "" || strings.ContainsAny(system.Name, ":| ") {
+		return ErrInvalidUnitSystem
+	}
+
+	base := 0
+	names := make(map[string]bool)
+	for _, unit := range system.Units {
+		if unit.Value <= 0 {
+			return ErrInvalidUnitSystem
+		}
+		if unit.Value == 1 {
+			base++
+		}
+		for _, name := range append([]string{unit.Name}, unit.Aliases...) {
+			name = strings.ToLower(name)
+			if name == "" || names[name] {
+				return ErrInvalidUnitSystem
+			}
+			names[name] = true
+		}
+	}
+	if base != 1 {
+		return ErrInvalidUnitSystem
+	}
+	if _, ok := system.unit(system.Preferred); system.Preferred != "" && !ok {
+		return ErrInvalidUnitSystem
+	}
+
+	unitSystems.Lock()
+	defer unitSystems.Unlock()
+	unitSystems.systems[system.Name] = system
+
+	return nil
+}
+
+//LookupUnitSystem returns the registered unit system with the provided name.
+func LookupUnitSystem(name string) (UnitSystem, error) {
+	unitSystems.RLock()
+	defer unitSystems.RUnlock()
+
+	system, ok := unitSystems.systems[name]
+	if !ok {
+		return UnitSystem{}, ErrUnitSystemDoesNotExist
+	}
+
+	return system, nil
+}
+
+//Parse returns the amount in value normalized to the base unit. Value can have more than one denomination
+//(e.g. 3 gp, 5 sp), and a number without a unit is in the base unit.
+func (s UnitSystem) Parse(value string) (float64, error) {
+	rest := strings.TrimSpace(value)
+	if rest == "" {
+		return 0, fmt.Errorf("%w, %q is not an amount of %s", ErrInvalidCellValue, value, s.Name)
+	}
+
+	total := 0.0
+	for rest != "" {
+		match := amountRE.FindStringSubmatch(rest)
+		if match == nil {
+			return 0, fmt.Errorf("%w, %q is not an amount of %s", ErrInvalidCellValue, value, s.Name)
+		}
+
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w, %q is not an amount of %s", ErrInvalidCellValue, value, s.Name)
+		}
+		unit, ok := s.unit(match[2])
+		if match[2] != "" && !ok {
+			return 0, fmt.Errorf("%w, %s is not a unit of %s", ErrUnitDoesNotExist, match[2], s.Name)
+		}
+		if match[2] == "" {
+			unit = Unit{Value: 1}
+		}
+
+		total += amount * unit.Value
+		rest = rest[len(match[0]):]
+	}
+
+	return total, nil
+}
+
+//Format returns amount, in base units, written in the system's preferred unit (e.g. 1250 copper is 12.5 gp).
+func (s UnitSystem) Format(amount float64) string {
+	unit, ok := s.unit(s.Preferred)
+	if !ok {
+		unit = s.base()
+	}
+
+	return formatNumber(amount/unit.Value) + " " + unit.Name
+}
+
+//Denominations returns amount, in base units, written using the fewest units from largest to smallest (e.g. 1250
+//copper is 1 pp, 2 gp, 1 ep). Anything smaller than the smallest unit is kept as a fraction of it.
+func (s UnitSystem) Denominations(amount float64) string {
+	units := append([]Unit(nil), s.Units...)
+	sort.Slice(units, func(i, j int) bool { return units[i].Value > units[j].Value })
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	var parts []string
+	for i, unit := range units {
+		count := math.Floor(amount/unit.Value + 1e-9)
+		if i == len(units)-1 {
+			count = amount / unit.Value
+		}
+		if count <= 1e-9 {
+			continue
+		}
+		amount = math.Max(0, amount-count*unit.Value)
+		parts = append(parts, formatNumber(count)+" "+unit.Name)
+	}
+	if len(parts) == 0 {
+		return "0 " + s.base().Name
+	}
+
+	return sign + strings.Join(parts, ", ")
+}
+
+//unit returns the unit with the provided name or alias, ignoring case.
+func (s UnitSystem) unit(name string) (Unit, bool) {
+	for _, unit := range s.Units {
+		for _, other := range append([]string{unit.Name}, unit.Aliases...) {
+			if strings.EqualFold(name, other) {
+				return unit, true
+			}
+		}
+	}
+
+	return Unit{}, false
+}
+
+//base returns the unit with a value of 1.
+func (s UnitSystem) base() Unit {
+	for _, unit := range s.Units {
+		if unit.Value == 1 {
+			return unit
+		}
+	}
+
+	return Unit{Value: 1}
+}
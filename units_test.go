@@ -0,0 +1,188 @@
+package tables
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUnitSystem_Parse(t *testing.T) {
+	tests := []struct {
+		name   string
+		system UnitSystem
+		value  string
+		want   float64
+	}{
+		{name: "validate an amount is normalized to the base unit", system: Coins, value: "25 gp", want: 2500},
+		{name: "validate denominations are added together", system: Coins, value: "3 gp, 5 sp 2cp", want: 352},
+		{name: "validate units ignore case", system: Coins, value: "1 PP", want: 1000},
+		{name: "validate an amount without a unit is in the base unit", system: Coins, value: "1,200", want: 1200},
+		{name: "validate aliases are units", system: Weight, value: "2 lbs 4 oz", want: 36},
+		{name: "validate decimal amounts", system: Weight, value: "0.5 lb", want: 8},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.system.Parse(test.value)
+			if err != nil {
+				t.Errorf("unexpected error, %s", err)
+			}
+			if got != test.want {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	testErrors := []struct {
+		name  string
+		value string
+		want  error
+	}{
+		{name: "validate an error is returned for a unit that is not in the system", value: "3 gp 2 dollars", want: ErrUnitDoesNotExist},
+		{name: "validate an error is returned for a value that is not an amount", value: "a few gp", want: ErrInvalidCellValue},
+		{name: "validate an error is returned for an empty value", value: " ", want: ErrInvalidCellValue},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Coins.Parse(test.value)
+			if !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestUnitSystem_Format(t *testing.T) {
+	tests := []struct {
+		amount        float64
+		format        string
+		denominations string
+	}{
+		{amount: 1250, format: "12.50 gp", denominations: "1 pp, 2 gp, 1 ep"},
+		{amount: 300, format: "3 gp", denominations: "3 gp"},
+		{amount: 7, format: "0.07 gp", denominations: "7 cp"},
+		{amount: 0, format: "0 gp", denominations: "0 cp"},
+		{amount: -110, format: "-1.10 gp", denominations: "-1 gp, 1 sp"},
+	}
+
+	for _, test := range tests {
+		t.Run("validate formatting "+formatNumber(test.amount), func(t *testing.T) {
+			if got := Coins.Format(test.amount); got != test.format {
+				t.Errorf("want %s, got %s", test.format, got)
+			}
+			if got := Coins.Denominations(test.amount); got != test.denominations {
+				t.Errorf("want %s, got %s", test.denominations, got)
+			}
+		})
+	}
+}
+
+func TestRegisterUnitSystem(t *testing.T) {
+	t.Run("validate a registered system can be used by value columns", func(t *testing.T) {
+		err := RegisterUnitSystem(UnitSystem{Name: "credits", Units: []Unit{{Name: "cr", Value: 1}, {Name: "kcr", Value: 1000}}})
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		table, err := Load([][]string{{"Bounty:value:credits"}, {"2 kcr"}, {"500 cr"}}, "bounties", "Bounties", "")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		got, err := table.Sum(table.Records(), "Bounty")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got != 2500 {
+			t.Errorf("want 2500, got %v", got)
+		}
+	})
+
+	testErrors := []struct {
+		name   string
+		system UnitSystem
+	}{
+		{name: "validate an error is returned without a name", system: UnitSystem{Units: []Unit{{Name: "a", Value: 1}}}},
+		{name: "validate an error is returned without a base unit", system: UnitSystem{Name: "bad", Units: []Unit{{Name: "a", Value: 2}}}},
+		{name: "validate an error is returned for units with the same name", system: UnitSystem{Name: "bad", Units: []Unit{{Name: "a", Value: 1}, {Name: "A", Value: 2}}}},
+		{name: "validate an error is returned for a unit without a value", system: UnitSystem{Name: "bad", Units: []Unit{{Name: "a", Value: 1}, {Name: "b"}}}},
+		{name: "validate an error is returned for a preferred unit that is not in the system", system: UnitSystem{Name: "bad", Units: []Unit{{Name: "a", Value: 1}}, Preferred: "b"}},
+	}
+
+	for _, test := range testErrors {
+		t.Run(test.name, func(t *testing.T) {
+			err := RegisterUnitSystem(test.system)
+			if err != ErrInvalidUnitSystem {
+				t.Errorf("want %v, got %v", ErrInvalidUnitSystem, err)
+			}
+		})
+	}
+}
+
+func TestTable_valueColumns(t *testing.T) {
+	hoard := [][]string{
+		{"D3", "Coins:value:coins", "Load:value:weight"},
+		{"1", "{{1d1}}0 sp", "1 lb"},
+		{"2", "2 gp, 5 sp", "8 oz"},
+		{"3", "1 pp", "2 lbs"},
+	}
+
+	table, err := Load(hoard, "hoard", "Hoard", "d3")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	library := NewLibrary(table)
+
+	t.Run("validate values are summed across rolled rows in the base unit", func(t *testing.T) {
+		records := [][]string{table.Meta.Headers}
+		for roll := 1; roll <= 3; roll++ {
+			row, err := table.GetRow(roll)
+			if err != nil {
+				t.Fatalf("unexpected error, %s", err)
+			}
+			records = append(records, row)
+		}
+
+		got, err := table.Sum(records, "Coins")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got != 1350 || Coins.Format(got) != "13.50 gp" {
+			t.Errorf("want 13.50 gp, got %s", Coins.Format(got))
+		}
+
+		got, err = table.Sum(records, "Load")
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if got != 56 || Weight.Format(got) != "3.50 lb" {
+			t.Errorf("want 3.50 lb, got %s", Weight.Format(got))
+		}
+	})
+
+	t.Run("validate simulations total values in their preferred unit", func(t *testing.T) {
+		simulation, err := library.Simulate(nil, "1#hoard", 2)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		var b bytes.Buffer
+		simulation.Report(&b)
+		if !strings.Contains(b.String(), "sum   2 gp") {
+			t.Errorf("want a sum of 2 gp, got %s", b.String())
+		}
+	})
+
+	t.Run("validate an unknown unit system is not a column type", func(t *testing.T) {
+		table, _ := Load([][]string{{"Cost:value:beads"}, {"3"}}, "beads", "Beads", "")
+		if table.Meta.ColumnTypes != nil || table.Meta.Headers[0] != "Cost:value:beads" {
+			t.Errorf("want an untyped Cost:value:beads column, got %v %v", table.Meta.Headers, table.Meta.ColumnTypes)
+		}
+
+		_, err := ColumnType("value:beads").UnitSystem()
+		if err != ErrUnitSystemDoesNotExist {
+			t.Errorf("want %v, got %v", ErrUnitSystemDoesNotExist, err)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package tables
+
+import (
+	"strings"
+)
+
+//Audience is who a table is shown to, columns can be hidden from everyone but a single audience (see View).
+type Audience string
+
+const (
+	//AudienceGM sees every column.
+	AudienceGM     Audience = "gm"
+	AudiencePlayer Audience = "player"
+)
+
+//GMColumnPrefix marks a column as only visible to AudienceGM (e.g. !Trap DC), the prefix is removed from the header
+//when the table is loaded.
+const GMColumnPrefix = "!"
+
+//Visible returns true if audience can see the column. AudienceGM can see every column, and other audiences can see
+//columns that are not limited to another audience. The roll column of a rollable table is always visible.
+func (t Table) Visible(column int, audience Audience) bool {
+	if audience == AudienceGM || column >= len(t.Meta.Visibility) || (column == 0 && t.Meta.RollableTable) {
+		return true
+	}
+	visibility := t.Meta.Visibility[column]
+
+	return visibility == "" || visibility == audience
+}
+
+//View returns the table as audience sees it, without the columns audience can't see. Row notes (see RowMetaNotes)
+//are only kept for AudienceGM.
+func (t Table) View(audience Audience) Table {
+	columns := t.visibleColumns(audience)
+
+	meta := t.Meta
+	meta.Headers = pick(t.Meta.Headers, columns)
+	meta.ColumnCount = len(meta.Headers)
+	if len(t.Meta.ColumnTypes) == t.Meta.ColumnCount {
+		types := make([]ColumnType, 0, len(columns))
+		for _, column := range columns {
+			types = append(types, t.Meta.ColumnTypes[column])
+		}
+		meta.ColumnTypes = types
+	}
+	if len(t.Meta.Visibility) == t.Meta.ColumnCount {
+		visibility := make([]Audience, 0, len(columns))
+		for _, column := range columns {
+			visibility = append(visibility, t.Meta.Visibility[column])
+		}
+		meta.Visibility = visibility
+	}
+	if t.Meta.Locales != nil {
+		meta.Locales = make(map[string]MetaLocale, len(t.Meta.Locales))
+		for locale, translation := range t.Meta.Locales {
+			translation.Headers = pick(translation.Headers, columns)
+			meta.Locales[locale] = translation
+		}
+	}
+
+	var rows []Row
+	if t.Rows != nil {
+		rows = make([]Row, len(t.Rows))
+	}
+	for i, row := range t.Rows {
+		row.Results = pick(row.Results, columns)
+		row.HasRollExpression = false
+		for _, result := range row.Results {
+			row.HasRollExpression = row.HasRollExpression || RollableString(result)
+		}
+		if row.Locales != nil {
+			locales := make(map[string][]string, len(row.Locales))
+			for locale, results := range row.Locales {
+				locales[locale] = pick(results, columns)
+			}
+			row.Locales = locales
+		}
+		if _, ok := row.Meta[RowMetaNotes]; ok && audience != AudienceGM {
+			rowMeta := make(map[string]string, len(row.Meta))
+			for key, value := range row.Meta {
+				if key != RowMetaNotes {
+					rowMeta[key] = value
+				}
+			}
+			row.Meta = rowMeta
+			if len(rowMeta) == 0 {
+				row.Meta = nil
+			}
+		}
+		rows[i] = row
+	}
+
+	return Table{Meta: meta, Rows: rows}
+}
+
+//ViewRecords returns records rolled from the table (e.g. by Expression) without the columns audience can't see.
+func (t Table) ViewRecords(records [][]string, audience Audience) [][]string {
+	columns := t.visibleColumns(audience)
+
+	view := make([][]string, len(records))
+	for i, record := range records {
+		view[i] = pick(record, columns)
+	}
+
+	return view
+}
+
+//visibleColumns returns the index of every column audience can see.
+func (t Table) visibleColumns(audience Audience) []int {
+	columns := make([]int, 0, t.Meta.ColumnCount)
+	for i := 0; i < t.Meta.ColumnCount; i++ {
+		if t.Visible(i, audience) {
+			columns = append(columns, i)
+		}
+	}
+
+	return columns
+}
+
+//splitVisibility removes GMColumnPrefix from headers, returning the headers and the audience of each column. The
+//audiences are nil if no header has the prefix.
+func splitVisibility(headers []string) ([]string, []Audience) {
+	var visibility []Audience
+	for i, header := range headers {
+		if !strings.HasPrefix(header, GMColumnPrefix) || len(header) == len(GMColumnPrefix) {
+			continue
+		}
+		if visibility == nil {
+			visibility = make([]Audience, len(headers))
+			headers = cloneStrings(headers)
+		}
+		headers[i] = strings.TrimPrefix(header, GMColumnPrefix)
+		visibility[i] = AudienceGM
+	}
+
+	return headers, visibility
+}
+
+//pick returns the values at the provided indexes, indexes past the end of values are skipped.
+func pick(values []string, indexes []int) []string {
+	if values == nil {
+		return nil
+	}
+
+	picked := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		if index < len(values) {
+			picked = append(picked, values[index])
+		}
+	}
+
+	return picked
+}
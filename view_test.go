@@ -0,0 +1,135 @@
+package tables
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+var trapsCSV = [][]string{
+	{"D3", "Trap", "!Trap DC:int", "Effect", "@notes"},
+	{"1", "Pit", "12", "{{1d1}}0 ft fall", "Covered with leaves."},
+	{"2", "Darts", "15", "Poison", ""},
+	{"3", "Rune", "18", "Fire", "Warded by the lich."},
+}
+
+func testTrapsTable(t *testing.T) Table {
+	table, err := Load(trapsCSV, "traps", "Traps", "d3")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	return table
+}
+
+//testDeepTrapsTable overrides a row of traps without repeating its gm column, which it inherits.
+func testDeepTrapsTable(t *testing.T) Table {
+	table, err := Load([][]string{{"D3", "Trap", "Trap DC", "Effect"}, {"2", "Needles", "20", "Sleep"}}, "deep_traps", "Deep Traps", "d3")
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	table.Meta.Parent = "traps"
+
+	return table
+}
+
+func TestTable_View(t *testing.T) {
+	table := testTrapsTable(t)
+
+	t.Run("validate gm columns are loaded from the header", func(t *testing.T) {
+		if want := []string{"D3", "Trap", "Trap DC", "Effect"}; !reflect.DeepEqual(want, table.Meta.Headers) {
+			t.Errorf("want %v, got %v", want, table.Meta.Headers)
+		}
+		if want := []Audience{"", "", AudienceGM, ""}; !reflect.DeepEqual(want, table.Meta.Visibility) {
+			t.Errorf("want %v, got %v", want, table.Meta.Visibility)
+		}
+		if table.ColumnType(2) != ColumnInt {
+			t.Errorf("want %s, got %s", ColumnInt, table.ColumnType(2))
+		}
+	})
+
+	t.Run("validate the gm sees the whole table", func(t *testing.T) {
+		got := table.View(AudienceGM)
+		if !reflect.DeepEqual(table, got) {
+			t.Errorf("want %v, got %v", table, got)
+		}
+	})
+
+	t.Run("validate players do not see hidden columns or notes", func(t *testing.T) {
+		got := table.View(AudiencePlayer)
+
+		want := [][]string{{"D3", "Trap", "Effect"}, {"1", "Pit", "{{1d1}}0 ft fall"}, {"2", "Darts", "Poison"}, {"3", "Rune", "Fire"}}
+		if !reflect.DeepEqual(want, got.Records()) {
+			t.Errorf("want %v, got %v", want, got.Records())
+		}
+		for _, row := range got.Rows {
+			if row.Meta != nil {
+				t.Errorf("want no notes, got %v", row.Meta)
+			}
+		}
+		if !got.Rows[0].HasRollExpression {
+			t.Errorf("want a roll expression in the first row")
+		}
+
+		err := got.Validate()
+		if err != nil {
+			t.Errorf("unexpected error, %s", err)
+		}
+		if table.Meta.ColumnCount != 4 || table.Rows[0].Meta[RowMetaNotes] == "" {
+			t.Errorf("want the table to be unchanged, got %v", table)
+		}
+	})
+
+	t.Run("validate columns can be limited to other audiences", func(t *testing.T) {
+		table := testTrapsTable(t)
+		table.Meta.Visibility[3] = "rogue"
+
+		got := table.View("rogue").Meta.Headers
+		if want := []string{"D3", "Trap", "Effect"}; !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+		got = table.View(AudiencePlayer).Meta.Headers
+		if want := []string{"D3", "Trap"}; !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate rolled records are redacted", func(t *testing.T) {
+		records, err := table.Expression("2#traps")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		got := table.ViewRecords(records, AudiencePlayer)
+		if want := [][]string{{"D3", "Trap", "Effect"}, {"2", "Darts", "Poison"}}; !reflect.DeepEqual(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("validate gm columns are kept when encoded and decoded", func(t *testing.T) {
+		var b bytes.Buffer
+		err := Encode(&b, FormatCSV, table)
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+
+		got, err := Decode(&b, FormatCSV, "traps")
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		got.Meta.DisplayName = table.Meta.DisplayName
+		if !reflect.DeepEqual(table, got) {
+			t.Errorf("want %v, got %v", table, got)
+		}
+	})
+
+	t.Run("validate tables with the wrong number of audiences are not valid", func(t *testing.T) {
+		table := testTrapsTable(t)
+		table.Meta.Visibility = table.Meta.Visibility[:2]
+
+		err := table.Validate()
+		if err != ErrInvalidColumnCount {
+			t.Errorf("want %v, got %v", ErrInvalidColumnCount, err)
+		}
+	})
+}